@@ -0,0 +1,66 @@
+// Package apierr maps errors to the status codes and JSON bodies that the
+// v1 and v2 APIs answer with.
+package apierr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"linkedlist/linkedlist"
+	"net/http"
+	"strings"
+)
+
+// Error is the body of every error response.
+type Error struct {
+	Status  int    `json:"-"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// New returns an error with status and message, coded after the status
+// text, such as "bad_request".
+func New(status int, message string) *Error {
+	code := strings.ToLower(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+// From returns err as an *Error. The linkedlist errors get their own code;
+// any other error is a 500 whose details are not exposed.
+func From(err error) *Error {
+	var e *Error
+	switch {
+	case errors.As(err, &e):
+		return e
+	case errors.Is(err, linkedlist.ErrIndexOutOfRange):
+		return &Error{Status: http.StatusNotFound, Code: "index_out_of_range", Message: "Index out of range"}
+	case errors.Is(err, linkedlist.ErrNotFound):
+		return &Error{Status: http.StatusNotFound, Code: "value_not_found", Message: "Value not found"}
+	case errors.Is(err, linkedlist.ErrCapacityExceeded):
+		return &Error{Status: http.StatusInsufficientStorage, Code: "capacity_exceeded", Message: "Capacity exceeded"}
+	case errors.Is(err, linkedlist.ErrNotSorted):
+		return &Error{Status: http.StatusConflict, Code: "list_not_sorted", Message: "List not sorted"}
+	case errors.Is(err, linkedlist.ErrCorrupt):
+		return &Error{Status: http.StatusInternalServerError, Code: "list_corrupt", Message: err.Error()}
+	case errors.Is(err, errors.ErrUnsupported):
+		return &Error{Status: http.StatusNotImplemented, Code: "unsupported", Message: "Not supported by storage backend"}
+	case errors.Is(err, context.DeadlineExceeded):
+		return &Error{Status: http.StatusGatewayTimeout, Code: "timeout", Message: "Request timed out"}
+	case errors.Is(err, linkedlist.ErrCanceled), errors.Is(err, context.Canceled):
+		return &Error{Status: http.StatusServiceUnavailable, Code: "canceled", Message: "Request canceled"}
+	default:
+		return New(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	}
+}
+
+// Write answers with err as JSON.
+func Write(w http.ResponseWriter, err error) {
+	e := From(err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.Status)
+	json.NewEncoder(w).Encode(e)
+}
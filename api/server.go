@@ -6,32 +6,82 @@ import (
 	v1 "linkedlist/api/v1"
 	v2 "linkedlist/api/v2"
 	"linkedlist/config"
+	"linkedlist/linkedlist"
 
 	"log/slog"
 	"net/http"
+	"sync"
+	"time"
 )
 
 type Api struct {
 	Mux    *http.ServeMux
 	Server *http.Server
+	// stop ends the periodic list check of debug builds. Shutdown closes
+	// it once, since a failed shutdown leaves the Api to be shut down again.
+	stop     chan struct{}
+	stopOnce sync.Once
 }
 
 func New() (*Api, error) {
-	v1 := v1.V1()
-	v2, err := v2.V2()
+	var opts []linkedlist.Option
+	if config.Confs.Storage.ValueIndex {
+		opts = append(opts, linkedlist.WithValueIndex())
+	}
+	if config.Confs.Storage.BloomCapacity > 0 {
+		opts = append(opts, linkedlist.WithBloomFilter(config.Confs.Storage.BloomCapacity, config.Confs.Storage.BloomFalsePositiveRate))
+	}
+	if config.Confs.Storage.Capacity > 0 {
+		opts = append(opts, linkedlist.WithCapacity(config.Confs.Storage.Capacity))
+	}
+
+	v1List, err := linkedlist.NewBackend(config.Confs.Storage.Backend, opts...)
+	if err != nil {
+		return nil, err
+	}
+	v2Backend := config.Confs.Storage.V2Backend
+	if v2Backend == "" {
+		v2Backend = config.Confs.Storage.Backend
+	}
+	v2List, err := linkedlist.NewBackend(v2Backend, opts...)
 	if err != nil {
 		return nil, err
 	}
+
+	v1 := v1.V1(v1List)
+	v2, err := v2.V2(v2List)
+	if err != nil {
+		return nil, err
+	}
+	timeout := config.Confs.Server.RequestTimeout
 	mux := http.NewServeMux()
-	mux.Handle("/v1/", http.StripPrefix("/v1", v1))
-	mux.Handle("/v2/", http.StripPrefix("/v2", v2))
+	mux.Handle("/v1/", withTimeout(http.StripPrefix("/v1", v1), timeout))
+	mux.Handle("/v2/", withTimeout(http.StripPrefix("/v2", v2), timeout))
 
-	return &Api{
-		Mux: mux,
-	}, nil
+	a := &Api{
+		Mux:  mux,
+		stop: make(chan struct{}),
+	}
+	a.watch()
+	return a, nil
+}
+
+// withTimeout cancels the context of every request after timeout, which
+// makes the list traversals of its handler give up. A timeout of 0 leaves
+// requests unbounded.
+func withTimeout(h http.Handler, timeout time.Duration) http.Handler {
+	if timeout <= 0 {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
 }
 
 func (a *Api) Shutdown(ctx context.Context) error {
+	a.stopOnce.Do(func() { close(a.stop) })
 	return a.Server.Shutdown(ctx)
 }
 
@@ -1,45 +1,138 @@
 package v1
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"linkedlist/api/apierr"
 	"linkedlist/linkedlist"
 	"net/http"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 type SafeLinkedList struct {
-	list  *linkedlist.LinkedList
+	list  linkedlist.List[int]
 	mutex sync.Mutex
 }
 
-func NewSafeLinkedList() *SafeLinkedList {
-	return &SafeLinkedList{list: linkedlist.NewLinkedList()}
+// current is the list of the last V1 call, which Validate and RebuildIndex
+// check.
+var current atomic.Pointer[SafeLinkedList]
+
+func NewSafeLinkedList(list linkedlist.List[int]) *SafeLinkedList {
+	return &SafeLinkedList{list: list}
 }
 
-func (s *SafeLinkedList) Find(n int) (index uint, found bool) {
+// Validate checks the invariants of the list. It returns
+// errors.ErrUnsupported for backends that cannot check them.
+func (s *SafeLinkedList) Validate() error {
+	validator, ok := s.list.(linkedlist.Validator)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	return s.list.Find(n)
+	return validator.Validate()
 }
 
-func (s *SafeLinkedList) Get(index uint) (int, bool) {
+// RebuildIndex repairs the list and checks it again.
+func (s *SafeLinkedList) RebuildIndex() error {
+	validator, ok := s.list.(linkedlist.Validator)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	validator.RebuildIndex()
+	return validator.Validate()
+}
+
+// Validate checks the list the v1 API serves.
+func Validate() error {
+	return current.Load().Validate()
+}
+
+// RebuildIndex repairs the list the v1 API serves.
+func RebuildIndex() error {
+	return current.Load().RebuildIndex()
+}
+
+func (s *SafeLinkedList) Find(ctx context.Context, n int) (uint, error) {
+	// A Bloom filter answers most misses without waiting for the lock.
+	if filter, ok := s.list.(linkedlist.BloomFiltered[int]); ok && !filter.MayContain(n) {
+		return 0, linkedlist.ErrNotFound
+	}
+
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	return s.list.Get(index)
+	return linkedlist.FindContext(ctx, s.list, n)
 }
 
-func (s *SafeLinkedList) Insert(index uint, val int) bool {
+func (s *SafeLinkedList) Get(ctx context.Context, index uint) (int, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	return s.list.Insert(index, val)
+	return linkedlist.GetContext(ctx, s.list, index)
 }
 
-func (s *SafeLinkedList) Remove(index uint) bool {
+func (s *SafeLinkedList) Insert(index uint, val int) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	return s.list.Remove(index)
+	return linkedlist.Insert(s.list, index, val)
+}
+
+func (s *SafeLinkedList) Remove(index uint) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return linkedlist.Remove(s.list, index)
+}
+
+func (s *SafeLinkedList) InsertAll(index uint, values []int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if index > s.list.Len() {
+		return linkedlist.ErrIndexOutOfRange
+	}
+	if err := linkedlist.CheckCapacity(s.list, uint(len(values))); err != nil {
+		return err
+	}
+	if !linkedlist.InsertAll(s.list, index, values) {
+		return linkedlist.ErrIndexOutOfRange
+	}
+	return nil
+}
+
+func (s *SafeLinkedList) Append(values ...int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := linkedlist.CheckCapacity(s.list, uint(len(values))); err != nil {
+		return err
+	}
+	linkedlist.Append(s.list, values...)
+	return nil
+}
+
+func (s *SafeLinkedList) RemoveRange(from, to uint) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !linkedlist.RemoveRange(s.list, from, to) {
+		return linkedlist.ErrIndexOutOfRange
+	}
+	return nil
+}
+
+func (s *SafeLinkedList) Slice(from, to uint) ([]int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	values, ok := linkedlist.Slice(s.list, from, to)
+	if !ok {
+		return nil, linkedlist.ErrIndexOutOfRange
+	}
+	return values, nil
 }
 
 func handleInsert(w http.ResponseWriter, r *http.Request, list *SafeLinkedList) {
@@ -49,13 +142,33 @@ func handleInsert(w http.ResponseWriter, r *http.Request, list *SafeLinkedList)
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		apierr.Write(w, apierr.New(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	if err := list.Insert(req.Index, req.Value); err != nil {
+		apierr.Write(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Insert successful"})
+}
+
+func handleInsertBatch(w http.ResponseWriter, r *http.Request, list *SafeLinkedList) {
+	var req struct {
+		Index  uint  `json:"index"`
+		Values []int `json:"values"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierr.Write(w, apierr.New(http.StatusBadRequest, "Invalid request payload"))
 		return
 	}
 
-	success := list.Insert(req.Index, req.Value)
-	if !success {
-		http.Error(w, "Index out of range", http.StatusNotFound)
+	if err := list.InsertAll(req.Index, req.Values); err != nil {
+		apierr.Write(w, err)
 		return
 	}
 
@@ -64,17 +177,83 @@ func handleInsert(w http.ResponseWriter, r *http.Request, list *SafeLinkedList)
 	json.NewEncoder(w).Encode(map[string]string{"message": "Insert successful"})
 }
 
+func handleAppend(w http.ResponseWriter, r *http.Request, list *SafeLinkedList) {
+	var req struct {
+		Values []int `json:"values"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierr.Write(w, apierr.New(http.StatusBadRequest, "Invalid request payload"))
+		return
+	}
+
+	if err := list.Append(req.Values...); err != nil {
+		apierr.Write(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]string{"message": "Append successful"})
+}
+
+// parseRange reads the {from} and {to} path values of a range route.
+func parseRange(r *http.Request) (from, to uint, ok bool) {
+	f, err := strconv.Atoi(r.PathValue("from"))
+	if err != nil || f < 0 {
+		return 0, 0, false
+	}
+	t, err := strconv.Atoi(r.PathValue("to"))
+	if err != nil || t < 0 {
+		return 0, 0, false
+	}
+	return uint(f), uint(t), true
+}
+
+func handleRemoveRange(w http.ResponseWriter, r *http.Request, list *SafeLinkedList) {
+	from, to, ok := parseRange(r)
+	if !ok {
+		apierr.Write(w, apierr.New(http.StatusBadRequest, "Invalid range"))
+		return
+	}
+
+	if err := list.RemoveRange(from, to); err != nil {
+		apierr.Write(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Remove successful"})
+}
+
+func handleSlice(w http.ResponseWriter, r *http.Request, list *SafeLinkedList) {
+	from, to, ok := parseRange(r)
+	if !ok {
+		apierr.Write(w, apierr.New(http.StatusBadRequest, "Invalid range"))
+		return
+	}
+
+	values, err := list.Slice(from, to)
+	if err != nil {
+		apierr.Write(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(values)
+}
+
 func handleGet(w http.ResponseWriter, r *http.Request, list *SafeLinkedList) {
 	indexStr := strings.TrimPrefix(r.URL.Path, "/get/")
 	index, err := strconv.Atoi(indexStr)
 	if err != nil {
-		http.Error(w, "Invalid index", http.StatusBadRequest)
+		apierr.Write(w, apierr.New(http.StatusBadRequest, "Invalid index"))
 		return
 	}
 
-	value, found := list.Get(uint(index))
-	if !found {
-		http.Error(w, "Index out of range", http.StatusNotFound)
+	value, err := list.Get(r.Context(), uint(index))
+	if err != nil {
+		apierr.Write(w, err)
 		return
 	}
 
@@ -86,13 +265,12 @@ func handleRemove(w http.ResponseWriter, r *http.Request, list *SafeLinkedList)
 	indexStr := strings.TrimPrefix(r.URL.Path, "/remove/")
 	index, err := strconv.Atoi(indexStr)
 	if err != nil {
-		http.Error(w, "Invalid index", http.StatusBadRequest)
+		apierr.Write(w, apierr.New(http.StatusBadRequest, "Invalid index"))
 		return
 	}
 
-	success := list.Remove(uint(index))
-	if !success {
-		http.Error(w, "Index out of range", http.StatusNotFound)
+	if err := list.Remove(uint(index)); err != nil {
+		apierr.Write(w, err)
 		return
 	}
 
@@ -104,13 +282,13 @@ func handleFind(w http.ResponseWriter, r *http.Request, list *SafeLinkedList) {
 	valueStr := strings.TrimPrefix(r.URL.Path, "/find/")
 	value, err := strconv.Atoi(valueStr)
 	if err != nil {
-		http.Error(w, "Invalid value", http.StatusBadRequest)
+		apierr.Write(w, apierr.New(http.StatusBadRequest, "Invalid value"))
 		return
 	}
 
-	index, found := list.Find(value)
-	if !found {
-		http.Error(w, "Value not found", http.StatusNotFound)
+	index, err := list.Find(r.Context(), value)
+	if err != nil {
+		apierr.Write(w, err)
 		return
 	}
 
@@ -118,36 +296,82 @@ func handleFind(w http.ResponseWriter, r *http.Request, list *SafeLinkedList) {
 	json.NewEncoder(w).Encode(map[string]uint{"index": index})
 }
 
-func handleList(w http.ResponseWriter, _ *http.Request, list *SafeLinkedList) {
+// handleList encodes the values as a JSON array into a buffer and writes it
+// only once the traversal completes. Streaming it would commit to a 200
+// before the walk could time out, leaving a truncated array; buffered, a
+// request whose context is done midway gets the timeout error instead. The
+// buffer holds the encoded array, about as large as the response.
+func handleList(w http.ResponseWriter, r *http.Request, list *SafeLinkedList) {
 	list.mutex.Lock()
 	defer list.mutex.Unlock()
 
-	values := list.list.HandleList()
+	ctx := r.Context()
+	buf := []byte{'['}
+	for i, value := range linkedlist.AllContext(ctx, list.list) {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = strconv.AppendInt(buf, int64(value), 10)
+	}
+	if ctx.Err() != nil {
+		apierr.Write(w, ctx.Err())
+		return
+	}
+	buf = append(buf, "]\n"...)
+
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(values)
+	w.Write(buf)
+}
+
+func handleValidate(w http.ResponseWriter, check func() error) {
+	if err := check(); err != nil {
+		apierr.Write(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]bool{"valid": true})
 }
 
-func V1() http.Handler {
-	list := NewSafeLinkedList()
+func V1(l linkedlist.List[int]) http.Handler {
+	list := NewSafeLinkedList(l)
+	current.Store(list)
 
 	h := http.NewServeMux()
 
 	h.HandleFunc("POST /insert", func(w http.ResponseWriter, r *http.Request) {
 		handleInsert(w, r, list)
 	})
+	h.HandleFunc("POST /insert/batch", func(w http.ResponseWriter, r *http.Request) {
+		handleInsertBatch(w, r, list)
+	})
+	h.HandleFunc("POST /append", func(w http.ResponseWriter, r *http.Request) {
+		handleAppend(w, r, list)
+	})
 	h.HandleFunc("GET /get/{index}", func(w http.ResponseWriter, r *http.Request) {
 		handleGet(w, r, list)
 	})
 	h.HandleFunc("DELETE /remove/{index}", func(w http.ResponseWriter, r *http.Request) {
 		handleRemove(w, r, list)
 	})
+	h.HandleFunc("DELETE /remove/{from}/{to}", func(w http.ResponseWriter, r *http.Request) {
+		handleRemoveRange(w, r, list)
+	})
+	h.HandleFunc("GET /slice/{from}/{to}", func(w http.ResponseWriter, r *http.Request) {
+		handleSlice(w, r, list)
+	})
 	h.HandleFunc("GET /find/{value}", func(w http.ResponseWriter, r *http.Request) {
 		handleFind(w, r, list)
 	})
 	h.HandleFunc("GET /list", func(w http.ResponseWriter, r *http.Request) {
 		handleList(w, r, list)
 	})
+	h.HandleFunc("GET /admin/validate", func(w http.ResponseWriter, r *http.Request) {
+		handleValidate(w, list.Validate)
+	})
+	h.HandleFunc("POST /admin/rebuild", func(w http.ResponseWriter, r *http.Request) {
+		handleValidate(w, list.RebuildIndex)
+	})
 
 	return h
 }
@@ -0,0 +1,61 @@
+package v2
+
+import (
+	"errors"
+	"linkedlist/linkedlist"
+	"net/http"
+
+	echo "github.com/labstack/echo/v4"
+)
+
+// validate checks the invariants of the list. It returns
+// errors.ErrUnsupported for backends that cannot check them.
+func (s *server) validate() error {
+	validator, ok := s.list.(linkedlist.Validator)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+
+	s.lock()
+	defer s.unlock()
+	return validator.Validate()
+}
+
+// rebuildIndex repairs the list and checks it again.
+func (s *server) rebuildIndex() error {
+	validator, ok := s.list.(linkedlist.Validator)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+
+	s.lock()
+	defer s.unlock()
+	validator.RebuildIndex()
+	return validator.Validate()
+}
+
+func (s *server) Validate(c echo.Context) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+	c.JSON(http.StatusOK, map[string]bool{"valid": true})
+	return nil
+}
+
+func (s *server) RebuildIndex(c echo.Context) error {
+	if err := s.rebuildIndex(); err != nil {
+		return err
+	}
+	c.JSON(http.StatusOK, map[string]bool{"valid": true})
+	return nil
+}
+
+// Validate checks the list the v2 API serves.
+func Validate() error {
+	return current.Load().validate()
+}
+
+// RebuildIndex repairs the list the v2 API serves.
+func RebuildIndex() error {
+	return current.Load().rebuildIndex()
+}
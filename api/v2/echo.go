@@ -1,7 +1,11 @@
 package v2
 
 import (
+	"cmp"
 	"context"
+	"errors"
+	"fmt"
+	"linkedlist/api/apierr"
 	"linkedlist/linkedlist"
 	"log/slog"
 	"net/http"
@@ -19,9 +23,24 @@ type ListEntity struct {
 	Value int  `json:"value" param:"value"`
 }
 
+type BatchEntity struct {
+	Index  uint  `json:"index"`
+	Values []int `json:"values" validate:"required"`
+}
+
+type RangeEntity struct {
+	From   uint  `json:"from" param:"from"`
+	To     uint  `json:"to" param:"to"`
+	Values []int `json:"values,omitempty"`
+}
+
 type server struct {
-	list  *linkedlist.LinkedList
+	list  linkedlist.List[int]
 	mutex sync.RWMutex
+	// concurrent is set for backends that are safe without a lock. Their
+	// reads skip mutex and their writes share it, so that only the batch
+	// routes, which take several writes, exclude each other.
+	concurrent bool
 }
 
 type customValidator struct {
@@ -37,7 +56,7 @@ func (cv *customValidator) Validate(i interface{}) error {
 	return nil
 }
 
-func V2() (*echo.Echo, error) {
+func V2(l linkedlist.List[int]) (*echo.Echo, error) {
 	e := echo.New()
 
 	logger := slog.Default()
@@ -64,29 +83,114 @@ func V2() (*echo.Echo, error) {
 	}))
 
 	e.Validator = &customValidator{validator: validator.New()}
+	e.HTTPErrorHandler = handleError
 
 	registerMetricsMiddleware.Do(func() {
 		e.Use(echoprometheus.NewMiddleware("myapp"))
 		e.GET("/metrics", echoprometheus.NewHandler())
+		registerListMetrics()
 	})
 
-	s := &server{}
-	l := linkedlist.NewLinkedList()
-	s.list = l
+	_, concurrent := l.(linkedlist.Concurrent)
+	s := &server{list: l, concurrent: concurrent}
+	current.Store(s)
 	e.POST("/numbers/:index/:value", s.Insert)
 	e.DELETE("/numbers/:index", s.Remove)
 	e.GET("/numbers/value/:value", s.Find)
 	e.GET("/numbers/index/:index", s.Get)
 
+	e.POST("/numbers/batch", s.InsertBatch)
+	e.POST("/numbers/append", s.Append)
+	e.DELETE("/numbers/range/:from/:to", s.RemoveRange)
+	e.GET("/numbers/range/:from/:to", s.Slice)
+
+	e.POST("/numbers/sort", s.Sort)
+	e.POST("/numbers/sorted/:value", s.InsertSorted)
+	e.GET("/numbers/sorted/value/:value", s.FindSorted)
+
 	e.GET("/numbers/rwmutex/value/:value", s.RWMutexFind)
 	e.GET("/numbers/rwmutex/index/:index", s.RWMutexGet)
 
 	e.GET("/numbers/concurrency/value/:value", s.ConcurrencySearchValue)
 	e.GET("/numbers/concurrency/index/:index", s.SearchInSegmentedNodes)
 
+	e.GET("/admin/validate", s.Validate)
+	e.POST("/admin/rebuild", s.RebuildIndex)
+
 	return e, nil
 }
 
+// handleError answers with the JSON body v1 uses for the same error.
+func handleError(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		err = apierr.New(he.Code, fmt.Sprint(he.Message))
+	}
+	e := apierr.From(err)
+	if c.Request().Method == http.MethodHead {
+		c.NoContent(e.Status)
+		return
+	}
+	c.JSON(e.Status, e)
+}
+
+func (s *server) lock() {
+	if s.concurrent {
+		s.mutex.RLock()
+		return
+	}
+	s.mutex.Lock()
+}
+
+func (s *server) unlock() {
+	if s.concurrent {
+		s.mutex.RUnlock()
+		return
+	}
+	s.mutex.Unlock()
+}
+
+// lockBatch excludes every other write on any backend, so that a batch of
+// writes on a backend without BulkList cannot be cut short by another one.
+func (s *server) lockBatch() {
+	s.mutex.Lock()
+}
+
+func (s *server) unlockBatch() {
+	s.mutex.Unlock()
+}
+
+func (s *server) rlock() {
+	if !s.concurrent {
+		s.mutex.RLock()
+	}
+}
+
+func (s *server) runlock() {
+	if !s.concurrent {
+		s.mutex.RUnlock()
+	}
+}
+
+// view returns the list a read handler should use and the function that
+// ends the read. A backend with snapshots is read from one without taking
+// mutex; any other is read under mutex, exclusively or shared.
+func (s *server) view(exclusive bool) (linkedlist.List[int], func()) {
+	if snapshotter, ok := s.list.(linkedlist.Snapshotter[int]); ok {
+		return snapshotter.Snapshot(), func() {}
+	}
+	if exclusive {
+		s.lock()
+		return s.list, s.unlock
+	}
+	s.rlock()
+	return s.list, s.runlock
+}
+
 func (s *server) Insert(c echo.Context) error {
 	data := ListEntity{}
 
@@ -97,17 +201,185 @@ func (s *server) Insert(c echo.Context) error {
 		return err
 	}
 
-	s.mutex.Lock()
-	ok := s.list.Insert(data.Index, data.Value)
-	s.mutex.Unlock()
+	s.lock()
+	err := linkedlist.Insert(s.list, data.Index, data.Value)
+	s.unlock()
+
+	if err != nil {
+		return err
+	}
+	c.JSON(http.StatusCreated, data)
+	return nil
+}
+
+func (s *server) InsertBatch(c echo.Context) error {
+	data := BatchEntity{}
+
+	if err := c.Bind(&data); err != nil {
+		return err
+	}
+	if err := c.Validate(&data); err != nil {
+		return err
+	}
+
+	s.lockBatch()
+	err := insertAll(s.list, data.Index, data.Values)
+	s.unlockBatch()
+
+	if err != nil {
+		return err
+	}
+	c.JSON(http.StatusCreated, data)
+	return nil
+}
+
+func (s *server) Append(c echo.Context) error {
+	data := BatchEntity{}
+
+	if err := c.Bind(&data); err != nil {
+		return err
+	}
+	if err := c.Validate(&data); err != nil {
+		return err
+	}
+
+	s.lockBatch()
+	data.Index = s.list.Len()
+	err := linkedlist.CheckCapacity(s.list, uint(len(data.Values)))
+	if err == nil {
+		linkedlist.Append(s.list, data.Values...)
+	}
+	s.unlockBatch()
+
+	if err != nil {
+		return err
+	}
+	c.JSON(http.StatusCreated, data)
+	return nil
+}
+
+func (s *server) RemoveRange(c echo.Context) error {
+	data := RangeEntity{}
+
+	if err := c.Bind(&data); err != nil {
+		return echo.NewHTTPError(echo.ErrBadRequest.Code, "Invalid range")
+	}
+
+	s.lockBatch()
+	ok := linkedlist.RemoveRange(s.list, data.From, data.To)
+	s.unlockBatch()
 
 	if !ok {
-		return echo.NewHTTPError(echo.ErrBadRequest.Code, "Invalid index")
+		return linkedlist.ErrIndexOutOfRange
+	}
+
+	c.NoContent(http.StatusOK)
+	return nil
+}
+
+func (s *server) Slice(c echo.Context) error {
+	data := RangeEntity{}
+
+	if err := c.Bind(&data); err != nil {
+		return echo.NewHTTPError(echo.ErrBadRequest.Code, "Invalid range")
+	}
+
+	list, done := s.view(false)
+	values, ok := linkedlist.Slice(list, data.From, data.To)
+	done()
+
+	if !ok {
+		return linkedlist.ErrIndexOutOfRange
+	}
+
+	data.Values = values
+	c.JSON(http.StatusOK, data)
+	return nil
+}
+
+// sorter returns the backend as a Sorter, or a 501 error when the backend
+// cannot sort.
+func (s *server) sorter() (linkedlist.Sorter[int], error) {
+	sorter, ok := s.list.(linkedlist.Sorter[int])
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusNotImplemented, "Sorting not supported by storage backend")
+	}
+	return sorter, nil
+}
+
+func (s *server) Sort(c echo.Context) error {
+	sorter, err := s.sorter()
+	if err != nil {
+		return err
+	}
+
+	s.lock()
+	sorter.Sort(cmp.Less[int])
+	s.unlock()
+
+	c.NoContent(http.StatusOK)
+	return nil
+}
+
+func (s *server) InsertSorted(c echo.Context) error {
+	sorter, err := s.sorter()
+	if err != nil {
+		return err
+	}
+
+	valueStr := c.Param("value")
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		return echo.NewHTTPError(echo.ErrBadRequest.Code, "Invalid value")
+	}
+
+	s.lock()
+	index, ok := sorter.InsertSorted(value)
+	sorted := sorter.Sorted()
+	s.unlock()
+
+	switch {
+	case !sorted:
+		return linkedlist.ErrNotSorted
+	case !ok:
+		return linkedlist.ErrCapacityExceeded
+	}
+	data := ListEntity{
+		Index: index,
+		Value: value,
 	}
 	c.JSON(http.StatusCreated, data)
 	return nil
 }
 
+func (s *server) FindSorted(c echo.Context) error {
+	sorter, err := s.sorter()
+	if err != nil {
+		return err
+	}
+
+	valueStr := c.Param("value")
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		return echo.NewHTTPError(echo.ErrBadRequest.Code, "Invalid value")
+	}
+
+	s.rlock()
+	index, ok := sorter.FindSorted(value)
+	s.runlock()
+
+	if !ok {
+		return linkedlist.ErrNotFound
+	}
+
+	data := ListEntity{
+		Index: index,
+		Value: value,
+	}
+	c.JSON(http.StatusOK, data)
+	return nil
+}
+
 func (s *server) Remove(c echo.Context) error {
 	indexStr := c.Param("index")
 	index, err := strconv.ParseUint(indexStr, 10, 32)
@@ -115,12 +387,12 @@ func (s *server) Remove(c echo.Context) error {
 		return echo.NewHTTPError(echo.ErrBadRequest.Code, "Invalid index")
 	}
 
-	s.mutex.Lock()
-	ok := s.list.Remove(uint(index))
-	s.mutex.Unlock()
+	s.lock()
+	err = linkedlist.Remove(s.list, uint(index))
+	s.unlock()
 
-	if !ok {
-		return echo.NewHTTPError(echo.ErrNotFound.Code, "Index not found")
+	if err != nil {
+		return err
 	}
 
 	c.NoContent(http.StatusOK)
@@ -134,12 +406,12 @@ func (s *server) Find(c echo.Context) error {
 		return echo.NewHTTPError(echo.ErrBadRequest.Code, "Invalid value")
 	}
 
-	s.mutex.Lock()
-	index, ok := s.list.Find(value)
-	s.mutex.Unlock()
+	list, done := s.view(true)
+	index, err := linkedlist.FindContext(c.Request().Context(), list, value)
+	done()
 
-	if !ok {
-		return echo.NewHTTPError(echo.ErrNotFound.Code, "Value not found")
+	if err != nil {
+		return err
 	}
 
 	data := ListEntity{
@@ -157,12 +429,12 @@ func (s *server) Get(c echo.Context) error {
 		return echo.NewHTTPError(echo.ErrBadRequest.Code, "Invalid index")
 	}
 
-	s.mutex.Lock()
-	value, ok := s.list.Get(uint(index))
-	s.mutex.Unlock()
+	list, done := s.view(true)
+	value, err := linkedlist.GetContext(c.Request().Context(), list, uint(index))
+	done()
 
-	if !ok {
-		return echo.NewHTTPError(echo.ErrNotFound.Code, "Index not found")
+	if err != nil {
+		return err
 	}
 	data := ListEntity{
 		Index: uint(index),
@@ -180,12 +452,12 @@ func (s *server) RWMutexFind(c echo.Context) error {
 		return echo.NewHTTPError(echo.ErrBadRequest.Code, "Invalid value")
 	}
 
-	s.mutex.RLock()
-	index, ok := s.list.Find(value)
-	s.mutex.RUnlock()
+	list, done := s.view(false)
+	index, err := linkedlist.FindContext(c.Request().Context(), list, value)
+	done()
 
-	if !ok {
-		return echo.NewHTTPError(echo.ErrNotFound.Code, "Value not found")
+	if err != nil {
+		return err
 	}
 
 	data := ListEntity{
@@ -203,15 +475,12 @@ func (s *server) SearchInSegmentedNodes(c echo.Context) error {
 		return echo.NewHTTPError(echo.ErrBadRequest.Code, "Invalid value")
 	}
 
-	ctx, cancel := context.WithCancel(c.Request().Context())
-	defer cancel()
-
-	s.mutex.RLock()
-	value, ok := s.list.SearchInSegmentedNodes(ctx, index)
-	s.mutex.RUnlock()
+	list, done := s.view(false)
+	value, err := searchIndex(c.Request().Context(), list, index)
+	done()
 
-	if !ok {
-		return echo.NewHTTPError(echo.ErrNotFound.Code, "Value not found")
+	if err != nil {
+		return err
 	}
 
 	data := ListEntity{
@@ -229,15 +498,12 @@ func (s *server) ConcurrencySearchValue(c echo.Context) error {
 		return echo.NewHTTPError(echo.ErrBadRequest.Code, "Invalid value")
 	}
 
-	ctx, cancel := context.WithCancel(c.Request().Context())
-	defer cancel()
-
-	s.mutex.RLock()
-	index, ok := s.list.SearchConcurrently(ctx, cancel, value)
-	s.mutex.RUnlock()
+	list, done := s.view(false)
+	index, err := linkedlist.Search(c.Request().Context(), list, value)
+	done()
 
-	if !ok {
-		return echo.NewHTTPError(echo.ErrNotFound.Code, "Value not found")
+	if err != nil {
+		return err
 	}
 
 	data := ListEntity{
@@ -255,12 +521,12 @@ func (s *server) RWMutexGet(c echo.Context) error {
 		return echo.NewHTTPError(echo.ErrBadRequest.Code, "Invalid index")
 	}
 
-	s.mutex.RLock()
-	value, ok := s.list.Get(uint(index))
-	s.mutex.RUnlock()
+	list, done := s.view(false)
+	value, err := linkedlist.GetContext(c.Request().Context(), list, uint(index))
+	done()
 
-	if !ok {
-		return echo.NewHTTPError(echo.ErrNotFound.Code, "Index not found")
+	if err != nil {
+		return err
 	}
 	data := ListEntity{
 		Index: uint(index),
@@ -270,3 +536,38 @@ func (s *server) RWMutexGet(c echo.Context) error {
 	c.JSON(http.StatusOK, data)
 	return nil
 }
+
+// insertAll inserts values at index after checking that they fit.
+func insertAll(list linkedlist.List[int], index uint, values []int) error {
+	if index > list.Len() {
+		return linkedlist.ErrIndexOutOfRange
+	}
+	if err := linkedlist.CheckCapacity(list, uint(len(values))); err != nil {
+		return err
+	}
+	if !linkedlist.InsertAll(list, index, values) {
+		return linkedlist.ErrIndexOutOfRange
+	}
+	return nil
+}
+
+// searchIndex uses the backend's segment search when it has one and falls
+// back to GetContext otherwise.
+func searchIndex(ctx context.Context, list linkedlist.List[int], index int) (int, error) {
+	if index < 0 {
+		return 0, linkedlist.ErrIndexOutOfRange
+	}
+	searcher, ok := list.(linkedlist.SegmentSearcher[int])
+	if !ok {
+		return linkedlist.GetContext(ctx, list, uint(index))
+	}
+
+	value, ok := searcher.SearchInSegmentedNodes(ctx, index)
+	if !ok {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		return 0, linkedlist.ErrIndexOutOfRange
+	}
+	return value, nil
+}
@@ -0,0 +1,71 @@
+package v2
+
+import (
+	"linkedlist/linkedlist"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// current is the server behind the list gauges. It is swapped on every V2
+// call, so the gauges follow the list across configuration reloads.
+var current atomic.Pointer[server]
+
+func registerListMetrics() {
+	prometheus.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: "myapp",
+			Name:      "list_segment_size",
+			Help:      "Number of nodes between two cached segment pointers of the v2 list.",
+		}, func() float64 {
+			return readList(current.Load(), func(l linkedlist.Segmented) float64 {
+				return float64(l.SegmentSize())
+			})
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: "myapp",
+			Name:      "list_segment_count",
+			Help:      "Number of cached segment pointers of the v2 list.",
+		}, func() float64 {
+			return readList(current.Load(), func(l linkedlist.Segmented) float64 {
+				return float64(l.SegmentCount())
+			})
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: "myapp",
+			Name:      "list_bloom_filter_size",
+			Help:      "Number of counters in the Bloom filter of the v2 list.",
+		}, func() float64 {
+			return readList(current.Load(), func(l linkedlist.BloomFiltered[int]) float64 {
+				return float64(l.FilterSize())
+			})
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: "myapp",
+			Name:      "list_bloom_false_positive_rate",
+			Help:      "Estimated share of lookups for absent values that the Bloom filter of the v2 list lets through.",
+		}, func() float64 {
+			return readList(current.Load(), func(l linkedlist.BloomFiltered[int]) float64 {
+				if l.FilterSize() == 0 {
+					return 0
+				}
+				return l.FalsePositiveRate()
+			})
+		}),
+	)
+}
+
+// readList reports 0 until V2 has run or when the backend does not
+// implement L.
+func readList[L any](s *server, read func(l L) float64) float64 {
+	if s == nil {
+		return 0
+	}
+	l, ok := s.list.(L)
+	if !ok {
+		return 0
+	}
+	s.rlock()
+	defer s.runlock()
+	return read(l)
+}
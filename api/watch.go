@@ -0,0 +1,6 @@
+//go:build !debug
+
+package api
+
+// watch checks the lists periodically in debug builds only.
+func (a *Api) watch() {}
@@ -0,0 +1,51 @@
+//go:build debug
+
+package api
+
+import (
+	"errors"
+	v1 "linkedlist/api/v1"
+	v2 "linkedlist/api/v2"
+	"log/slog"
+	"time"
+)
+
+// watchInterval is how often debug builds check the lists.
+const watchInterval = 10 * time.Second
+
+// watch checks the invariants of both lists every watchInterval until the
+// API shuts down. A violation is logged and the list repaired, so that a
+// bug in the list shows up in the logs instead of in wrong answers.
+func (a *Api) watch() {
+	checks := []struct {
+		api      string
+		validate func() error
+		rebuild  func() error
+	}{
+		{"v1", v1.Validate, v1.RebuildIndex},
+		{"v2", v2.Validate, v2.RebuildIndex},
+	}
+
+	go func() {
+		ticker := time.NewTicker(watchInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-a.stop:
+				return
+			case <-ticker.C:
+			}
+
+			for _, check := range checks {
+				err := check.validate()
+				if err == nil || errors.Is(err, errors.ErrUnsupported) {
+					continue
+				}
+				slog.Error("List invariant violated", "api", check.api, "error", err)
+				if err := check.rebuild(); err != nil {
+					slog.Error("Rebuilding list", "api", check.api, "error", err)
+				}
+			}
+		}
+	}()
+}
@@ -19,29 +19,25 @@ FIND_FILE="finds.txt"
 NUM_ENTRIES=100000
 
 # Create random insert JSON
-echo "[" > $INSERT_FILE
+echo "{\"values\": [" > $INSERT_FILE
 for ((i=0; i<$NUM_ENTRIES; i++)); do
   VALUE=$((RANDOM))
   if [ $i -eq $((NUM_ENTRIES-1)) ]; then
-    echo "{\"index\": $i, \"value\": $VALUE}" >> $INSERT_FILE
+    echo "$VALUE" >> $INSERT_FILE
     echo "$VALUE" >> $FIND_FILE
   else
-    echo "{\"index\": $i, \"value\": $VALUE}," >> $INSERT_FILE
+    echo "$VALUE," >> $INSERT_FILE
     echo "$VALUE" >> $FIND_FILE
   fi
 done
-echo "]" >> $INSERT_FILE
+echo "]}" >> $INSERT_FILE
 
-# Insert the numbers into the server
+# Insert the numbers into the server in a single batch request
 echo "Inserting numbers into the server..."
-while IFS= read -r line; do
-  index=$(echo $line | sed 's/.*"index": \([0-9]*\).*/\1/')
-  value=$(echo $line | sed 's/.*"value": \([0-9]*\).*/\1/')
-  if ! curl -s -X POST -H "Content-Type: application/json" -d "$line" http://localhost:8080/v2/numbers/$index/$value > /dev/null; then
-    echo "Error inserting $line"
-    exit 1
-  fi
-done < <(tail -n +2 $INSERT_FILE | head -n -1 | sed 's/,$//')
+if ! curl -sf -X POST -H "Content-Type: application/json" --data-binary "@$INSERT_FILE" http://localhost:8080/v2/numbers/append > /dev/null; then
+  echo "Error inserting numbers"
+  exit 1
+fi
 
 # Create a script for wrk to use for POST requests
 cat <<EOF > post.lua
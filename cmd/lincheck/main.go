@@ -0,0 +1,180 @@
+// Command lincheck runs concurrent clients against a list, records the
+// history of their operations and checks that it is linearizable. When it is
+// not, it prints a small sub-history that is not linearizable either.
+//
+// It runs against the /v1 and /v2 routes of a running server:
+//
+//	go run ./cmd/lincheck -url http://localhost:8080 -api v2
+//
+// or against a list of this process:
+//
+//	go run ./cmd/lincheck -backend lockfree
+//
+// It exits with status 1 when it finds a violation and 2 when the check
+// runs out of time.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"linkedlist/lincheck"
+	"linkedlist/linkedlist"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	url      = flag.String("url", "", "server to check, such as http://localhost:8080; empty checks -backend in process")
+	apis     = flag.String("api", "v1,v2", "comma separated routes of the server to check")
+	backend  = flag.String("backend", linkedlist.BackendLinkedList, "list to check in process")
+	clients  = flag.Int("clients", 4, "concurrent clients")
+	ops      = flag.Int("ops", 100, "operations of each client")
+	values   = flag.Int("values", 4, "bound of the values and indexes of the operations")
+	seed     = flag.Uint64("seed", uint64(time.Now().UnixNano()), "seed of the operations")
+	timeout  = flag.Duration("timeout", time.Minute, "time to check and minimize each history")
+	save     = flag.String("save", "", "file to write the recorded history to as JSON")
+	history  = flag.String("history", "", "JSON history to check instead of recording one")
+	errFound = errors.New("history is not linearizable")
+)
+
+func main() {
+	flag.Parse()
+
+	err := run(context.Background())
+	switch {
+	case errors.Is(err, errFound):
+		os.Exit(1)
+	case errors.Is(err, context.DeadlineExceeded):
+		fmt.Fprintln(os.Stderr, "lincheck: check timed out, the history may or may not be linearizable")
+		os.Exit(2)
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "lincheck:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context) error {
+	if *history != "" {
+		data, err := os.ReadFile(*history)
+		if err != nil {
+			return err
+		}
+		var h lincheck.History
+		if err := json.Unmarshal(data, &h); err != nil {
+			return fmt.Errorf("reading %s: %w", *history, err)
+		}
+		return check(ctx, *history, h)
+	}
+
+	ts, err := targets()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("seed %d\n", *seed)
+	cfg := lincheck.Config{Clients: *clients, Operations: *ops, Values: *values, Seed: *seed}
+	var found error
+	for _, t := range ts {
+		h, err := lincheck.Record(ctx, t.client, cfg)
+		if err != nil {
+			return fmt.Errorf("%s: %w", t.name, err)
+		}
+		if err := write(t.name, len(ts) > 1, h); err != nil {
+			return err
+		}
+		if err := check(ctx, t.name, h); errors.Is(err, errFound) {
+			found = err
+		} else if err != nil {
+			return err
+		}
+	}
+	return found
+}
+
+// target is a list to check and the name that reports it.
+type target struct {
+	name   string
+	client lincheck.Client
+}
+
+// targets returns a target for every route of -api, or one for -backend
+// when there is no -url.
+func targets() ([]target, error) {
+	if *url == "" {
+		l, err := linkedlist.NewBackend(*backend)
+		if err != nil {
+			return nil, err
+		}
+		return []target{{*backend, lincheck.NewListClient(l)}}, nil
+	}
+
+	var ts []target
+	client := &http.Client{Timeout: 10 * time.Second}
+	base := strings.TrimSuffix(*url, "/")
+	for _, api := range strings.Split(*apis, ",") {
+		switch api = strings.TrimSpace(api); api {
+		case "v1":
+			ts = append(ts, target{api, lincheck.NewV1Client(base+"/v1", client)})
+		case "v2":
+			ts = append(ts, target{api, lincheck.NewV2Client(base+"/v2", client)})
+		default:
+			return nil, fmt.Errorf("unknown api %q", api)
+		}
+	}
+	return ts, nil
+}
+
+// write saves h to -save, with the name of its target before the extension
+// when there are several.
+func write(name string, several bool, h lincheck.History) error {
+	if *save == "" {
+		return nil
+	}
+	path := *save
+	if several {
+		ext := filepath.Ext(path)
+		path = strings.TrimSuffix(path, ext) + "." + name + ext
+	}
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// check reports whether h is linearizable and prints a minimal violation
+// when it is not.
+func check(ctx context.Context, name string, h lincheck.History) error {
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	pending := 0
+	for _, op := range h.Operations {
+		if op.Pending {
+			pending++
+		}
+	}
+
+	ok, err := lincheck.Check(ctx, h)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if ok {
+		fmt.Printf("%s: linearizable, %d operations (%d pending)\n", name, len(h.Operations), pending)
+		return nil
+	}
+
+	minimal, err := lincheck.Minimize(ctx, h)
+	if err != nil {
+		fmt.Printf("%s: not linearizable, minimizing timed out at %d of %d operations:\n%v", name, len(minimal.Operations), len(h.Operations), minimal)
+		return errFound
+	}
+	fmt.Printf("%s: not linearizable, minimal violation of %d of %d operations:\n%v", name, len(minimal.Operations), len(h.Operations), minimal)
+	return errFound
+}
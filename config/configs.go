@@ -3,6 +3,7 @@ package config
 import (
 	"log/slog"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -17,12 +18,31 @@ var MapLevel = map[string]slog.Level{
 var Confs Config
 
 type Config struct {
-	Server server `yaml:"server"`
-	Logger logger `yaml:"logger"`
+	Server  server  `yaml:"server"`
+	Logger  logger  `yaml:"logger"`
+	Storage storage `yaml:"storage"`
 }
 
 type server struct {
 	Port uint `yaml:"port"`
+	// RequestTimeout bounds every request, such as "5s"; 0 leaves them
+	// unbounded.
+	RequestTimeout time.Duration `yaml:"request_timeout"`
+}
+
+type storage struct {
+	Backend string `yaml:"backend"`
+	// V2Backend overrides Backend for the v2 API when set.
+	V2Backend string `yaml:"v2_backend"`
+	// ValueIndex keeps a value index on linkedlist backends.
+	ValueIndex bool `yaml:"value_index"`
+	// BloomCapacity sizes a Bloom filter on linkedlist backends; 0 keeps
+	// no filter.
+	BloomCapacity          uint    `yaml:"bloom_capacity"`
+	BloomFalsePositiveRate float64 `yaml:"bloom_false_positive_rate"`
+	// Capacity limits linkedlist backends to that many values; 0 keeps
+	// them unbounded.
+	Capacity uint `yaml:"capacity"`
 }
 
 type logger struct {
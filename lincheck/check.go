@@ -0,0 +1,456 @@
+package lincheck
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"slices"
+)
+
+// step applies op to the list state of a sequential model. It reports
+// whether op could have returned its output from state, and the state after
+// it. A pending operation may return anything, and takes effect when its
+// index is in range.
+func step(state []int, op Operation) (bool, []int) {
+	in, out := op.Input, op.Output
+	switch in.Kind {
+	case Insert:
+		valid := in.Index <= uint(len(state))
+		if !op.Pending && out.OK != valid {
+			return false, state
+		}
+		if valid {
+			return true, slices.Insert(slices.Clone(state), int(in.Index), in.Value)
+		}
+		return true, state
+	case Remove:
+		valid := in.Index < uint(len(state))
+		if !op.Pending && out.OK != valid {
+			return false, state
+		}
+		if valid {
+			return true, slices.Delete(slices.Clone(state), int(in.Index), int(in.Index)+1)
+		}
+		return true, state
+	case Get:
+		if op.Pending {
+			return true, state
+		}
+		valid := in.Index < uint(len(state))
+		return out.OK == valid && (!valid || out.Value == state[in.Index]), state
+	case Find:
+		if op.Pending {
+			return true, state
+		}
+		i := slices.Index(state, in.Value)
+		return out.OK == (i >= 0) && (i < 0 || out.Index == uint(i)), state
+	default:
+		return false, state
+	}
+}
+
+// entry is a call or a return of an operation in the time ordered list the
+// checker walks.
+type entry struct {
+	op         int
+	isReturn   bool
+	time       int64
+	match      *entry
+	prev, next *entry
+}
+
+// lift unlinks the call e and its return.
+func lift(e *entry) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	m := e.match
+	m.prev.next = m.next
+	if m.next != nil {
+		m.next.prev = m.prev
+	}
+}
+
+// unlift links the call e and its return back where lift took them from.
+func unlift(e *entry) {
+	m := e.match
+	m.prev.next = m
+	if m.next != nil {
+		m.next.prev = m
+	}
+	e.prev.next = e
+	e.next.prev = e
+}
+
+// entries returns the calls and returns of ops after a sentinel, ordered
+// by time with calls first on ties, which treats touching operations as
+// concurrent.
+func entries(ops []Operation) *entry {
+	list := make([]*entry, 0, 2*len(ops))
+	for i, op := range ops {
+		call := &entry{op: i, time: op.Call}
+		ret := &entry{op: i, isReturn: true, time: op.end(), match: call}
+		call.match = ret
+		list = append(list, call, ret)
+	}
+	slices.SortStableFunc(list, func(a, b *entry) int {
+		switch {
+		case a.time < b.time:
+			return -1
+		case a.time > b.time:
+			return 1
+		case a.isReturn == b.isReturn:
+			return 0
+		case b.isReturn:
+			return -1
+		default:
+			return 1
+		}
+	})
+
+	head := &entry{}
+	prev := head
+	for _, e := range list {
+		prev.next, e.prev = e, prev
+		prev = e
+	}
+	return head
+}
+
+// bitset records which operations are linearized.
+type bitset []uint64
+
+func (b bitset) set(i int)   { b[i/64] |= 1 << (i % 64) }
+func (b bitset) clear(i int) { b[i/64] &^= 1 << (i % 64) }
+
+// key identifies a set of linearized operations and the state they leave,
+// which the checker need not explore twice.
+func key(linearized bitset, state []int) string {
+	buf := make([]byte, 0, 8*(len(linearized)+len(state)+1))
+	for _, word := range linearized {
+		buf = binary.LittleEndian.AppendUint64(buf, word)
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(state)))
+	for _, v := range state {
+		buf = binary.AppendVarint(buf, int64(v))
+	}
+	return string(buf)
+}
+
+// Check reports whether h is linearizable against a sequential list that
+// starts as h.Initial. It is the algorithm of Wing and Gong with the
+// memoization of Lowe: it linearizes the operations that may go next one
+// at a time, backtracking when an operation returns before it could be
+// linearized, and never revisits a set of linearized operations that
+// leaves the same list. It returns ctx.Err() if ctx is done first.
+func Check(ctx context.Context, h History) (bool, error) {
+	return search(ctx, h, func([]int) bool { return false })
+}
+
+// finals returns every list that a linearization of h can leave, which is
+// none if h is not linearizable.
+func finals(ctx context.Context, h History) ([][]int, error) {
+	var lists [][]int
+	seen := make(map[string]struct{})
+	_, err := search(ctx, h, func(state []int) bool {
+		k := key(nil, state)
+		if _, ok := seen[k]; !ok {
+			seen[k] = struct{}{}
+			lists = append(lists, state)
+		}
+		return true
+	})
+	return lists, err
+}
+
+// search looks for linearizations of h and calls visit with the list each
+// one leaves, until visit returns false. It reports whether it found any.
+func search(ctx context.Context, h History, visit func(state []int) bool) (bool, error) {
+	type frame struct {
+		call  *entry
+		state []int
+	}
+
+	ops := h.Operations
+	head := entries(ops)
+	linearized := make(bitset, (len(ops)+63)/64)
+	seen := make(map[string]struct{})
+	var stack []frame
+
+	completed := 0
+	for _, op := range ops {
+		if !op.Pending {
+			completed++
+		}
+	}
+
+	state := slices.Clone(h.Initial)
+	e := head.next
+
+	// backtrack undoes the last choice so that the search goes on with the
+	// next operation instead. It reports false when there is none left.
+	backtrack := func() bool {
+		if len(stack) == 0 {
+			return false
+		}
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		state = top.state
+		linearized.clear(top.call.op)
+		if !ops[top.call.op].Pending {
+			completed++
+		}
+		unlift(top.call)
+		e = top.call.next
+		return true
+	}
+
+	found := false
+	for steps := 0; ; steps++ {
+		if steps%4096 == 0 && ctx.Err() != nil {
+			return found, ctx.Err()
+		}
+
+		if completed == 0 {
+			found = true
+			if !visit(state) || !backtrack() {
+				return true, nil
+			}
+			continue
+		}
+
+		if !e.isReturn {
+			op := ops[e.op]
+			if ok, next := step(state, op); ok {
+				linearized.set(e.op)
+				k := key(linearized, next)
+				if _, ok := seen[k]; !ok {
+					seen[k] = struct{}{}
+					stack = append(stack, frame{call: e, state: state})
+					state = next
+					if !op.Pending {
+						completed--
+					}
+					lift(e)
+					e = head.next
+					continue
+				}
+				linearized.clear(e.op)
+			}
+			e = e.next
+			continue
+		}
+
+		// An operation returned before any order could place it.
+		if !backtrack() {
+			return found, nil
+		}
+	}
+}
+
+// droppable reports whether dropping the operation at i from h cannot make
+// the other operations inexplicable. That holds for the operations that do
+// not change the list, which are reads and failed writes, and for an
+// operation called after every other one returned, which is linearized
+// last.
+func droppable(h History, i int) bool {
+	op := h.Operations[i]
+	if op.Input.Kind == Get || op.Input.Kind == Find {
+		return true
+	}
+	if !op.Pending && !op.Output.OK {
+		return true
+	}
+	for j, other := range h.Operations {
+		if j != i && other.end() >= op.Call {
+			return false
+		}
+	}
+	return true
+}
+
+// Minimize returns a sub-history of the non-linearizable history h that is
+// not linearizable either, so that every violation it reports is one of h.
+// It first keeps the shortest prefix of h that is not linearizable. Then it
+// cuts h where no operation is in flight, keeping the operations before
+// the cut if they are not linearizable already, or replacing them by the
+// list they leave if they can only leave one. Then it drops droppable
+// operations, large groups first and halving them down to single ones,
+// until none can be dropped. It returns ctx.Err() with the smallest history
+// found so far if ctx is done first.
+func Minimize(ctx context.Context, h History) (History, error) {
+	h, err := shortestPrefix(ctx, h)
+	if err != nil {
+		return h, err
+	}
+	for {
+		cut, err := cut(ctx, h)
+		if err != nil {
+			return h, err
+		}
+		h = cut
+
+		var candidates []int
+		for i := range h.Operations {
+			if droppable(h, i) {
+				candidates = append(candidates, i)
+			}
+		}
+
+		dropped := false
+		for size := max(len(candidates)/2, 1); size > 0 && len(candidates) > 0 && !dropped; size /= 2 {
+			for start := 0; start < len(candidates); start += size {
+				group := candidates[start:min(start+size, len(candidates))]
+				sub := without(h, group)
+				ok, err := Check(ctx, sub)
+				if err != nil {
+					return h, err
+				}
+				if !ok {
+					h, dropped = sub, true
+					break
+				}
+			}
+		}
+		if !dropped {
+			return h, nil
+		}
+	}
+}
+
+// shortestPrefix returns the shortest prefix of the non-linearizable
+// history h that is not linearizable either. A linearization of h ordered up
+// to any time is one of the prefix of h up to that time, so a prefix that is
+// not linearizable is a violation of h, and so are the longer ones.
+func shortestPrefix(ctx context.Context, h History) (History, error) {
+	var returns []int64
+	for _, op := range h.Operations {
+		if !op.Pending {
+			returns = append(returns, op.Return)
+		}
+	}
+	slices.Sort(returns)
+	returns = slices.Compact(returns)
+	if len(returns) == 0 {
+		return h, nil
+	}
+
+	// The prefix up to the last return is as good as h, which is not linearizable.
+	lo, hi := 0, len(returns)-1
+	for lo < hi {
+		mid := (lo + hi) / 2
+		ok, err := Check(ctx, prefix(h, returns[mid]))
+		if err != nil {
+			return prefix(h, returns[hi]), err
+		}
+		if ok {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return prefix(h, returns[hi]), nil
+}
+
+// prefix returns the operations of h called by t, with the ones that had
+// not returned by then pending.
+func prefix(h History, t int64) History {
+	p := History{Initial: h.Initial}
+	for _, op := range h.Operations {
+		if op.Call > t {
+			continue
+		}
+		if op.end() > t {
+			op.Pending, op.Return, op.Output = true, 0, Output{}
+		}
+		p.Operations = append(p.Operations, op)
+	}
+	return p
+}
+
+// cut returns the part of the non-linearizable history h on one side of the
+// earliest time when no operation is in flight that is not linearizable on
+// its own, or h if there is none.
+func cut(ctx context.Context, h History) (History, error) {
+	var quiet []int64
+	for _, op := range h.Operations {
+		t := op.end()
+		inFlight := false
+		for _, other := range h.Operations {
+			if other.Call <= t && other.end() > t {
+				inFlight = true
+				break
+			}
+		}
+		if !inFlight && t != math.MaxInt64 {
+			quiet = append(quiet, t)
+		}
+	}
+	slices.Sort(quiet)
+	quiet = slices.Compact(quiet)
+
+	// Operations called after every operation before the cut returned are
+	// linearized after them, so a prefix that is not linearizable is a
+	// violation of h.
+	for _, t := range quiet {
+		before, _ := split(h, t)
+		if len(before.Operations) == len(h.Operations) {
+			break
+		}
+		ok, err := Check(ctx, before)
+		if err != nil {
+			return h, err
+		}
+		if !ok {
+			return before, nil
+		}
+	}
+
+	// A linearizable prefix that can only leave one list is the same as
+	// starting from that list.
+	for _, t := range slices.Backward(quiet) {
+		before, after := split(h, t)
+		if len(after.Operations) == 0 {
+			continue
+		}
+		lists, err := finals(ctx, before)
+		if err != nil {
+			return h, err
+		}
+		if len(lists) != 1 {
+			continue
+		}
+		after.Initial = lists[0]
+		ok, err := Check(ctx, after)
+		if err != nil {
+			return h, err
+		}
+		if !ok {
+			return after, nil
+		}
+	}
+	return h, nil
+}
+
+// split returns the operations of h that returned by t and the others.
+func split(h History, t int64) (before, after History) {
+	before.Initial = h.Initial
+	for _, op := range h.Operations {
+		if op.end() <= t {
+			before.Operations = append(before.Operations, op)
+		} else {
+			after.Operations = append(after.Operations, op)
+		}
+	}
+	return before, after
+}
+
+// without returns h without the operations at the sorted indexes drop.
+func without(h History, drop []int) History {
+	ops := make([]Operation, 0, len(h.Operations)-len(drop))
+	for i, op := range h.Operations {
+		if _, found := slices.BinarySearch(drop, i); !found {
+			ops = append(ops, op)
+		}
+	}
+	return History{Initial: h.Initial, Operations: ops}
+}
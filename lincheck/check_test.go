@@ -0,0 +1,226 @@
+package lincheck
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	v1 "linkedlist/api/v1"
+	v2 "linkedlist/api/v2"
+	"linkedlist/linkedlist"
+)
+
+// op returns a completed operation of client over [call, ret].
+func op(client int, call, ret int64, in Input, out Output) Operation {
+	return Operation{Client: client, Input: in, Output: out, Call: call, Return: ret}
+}
+
+func insert(index uint, value int) Input { return Input{Kind: Insert, Index: index, Value: value} }
+func remove(index uint) Input            { return Input{Kind: Remove, Index: index} }
+func get(index uint) Input               { return Input{Kind: Get, Index: index} }
+func find(value int) Input               { return Input{Kind: Find, Value: value} }
+
+var (
+	ok   = Output{OK: true}
+	fail = Output{}
+)
+
+func value(v int) Output  { return Output{OK: true, Value: v} }
+func index(i uint) Output { return Output{OK: true, Index: i} }
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		h    History
+		want bool
+	}{
+		{
+			name: "sequential",
+			h: History{Operations: []Operation{
+				op(0, 0, 1, insert(0, 7), ok),
+				op(0, 2, 3, get(0), value(7)),
+				op(0, 4, 5, find(7), index(0)),
+				op(0, 6, 7, remove(1), fail),
+				op(0, 8, 9, remove(0), ok),
+				op(0, 10, 11, get(0), fail),
+			}},
+			want: true,
+		},
+		{
+			name: "concurrent reads see either order",
+			h: History{Operations: []Operation{
+				op(0, 0, 10, insert(0, 1), ok),
+				op(1, 0, 10, insert(0, 2), ok),
+				op(2, 11, 12, get(0), value(1)),
+				op(2, 13, 14, get(1), value(2)),
+			}},
+			want: true,
+		},
+		{
+			name: "read concurrent with a write sees it or not",
+			h: History{Initial: []int{5}, Operations: []Operation{
+				op(0, 0, 10, remove(0), ok),
+				op(1, 1, 2, get(0), value(5)),
+				op(1, 3, 4, get(0), fail),
+			}},
+			want: true,
+		},
+		{
+			name: "stale read after the write returned",
+			h: History{Initial: []int{5}, Operations: []Operation{
+				op(0, 0, 1, remove(0), ok),
+				op(1, 2, 3, get(0), value(5)),
+			}},
+			want: false,
+		},
+		{
+			name: "reads disagree on the order of two writes",
+			h: History{Operations: []Operation{
+				op(0, 0, 10, insert(0, 1), ok),
+				op(1, 0, 10, insert(0, 2), ok),
+				op(2, 11, 12, find(1), index(0)),
+				op(3, 11, 12, find(2), index(0)),
+			}},
+			want: false,
+		},
+		{
+			name: "pending write may have happened",
+			h: History{Operations: []Operation{
+				{Client: 0, Input: insert(0, 3), Call: 0, Pending: true},
+				op(1, 5, 6, get(0), value(3)),
+			}},
+			want: true,
+		},
+		{
+			name: "pending write may not have happened",
+			h: History{Operations: []Operation{
+				{Client: 0, Input: insert(0, 3), Call: 0, Pending: true},
+				op(1, 5, 6, get(0), fail),
+			}},
+			want: true,
+		},
+		{
+			name: "pending write happens at most once",
+			h: History{Operations: []Operation{
+				{Client: 0, Input: insert(0, 3), Call: 0, Pending: true},
+				op(1, 5, 6, get(1), value(3)),
+			}},
+			want: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Check(context.Background(), test.h)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Fatalf("Check() = %t, expected %t for\n%v", got, test.want, test.h)
+			}
+		})
+	}
+}
+
+func TestMinimize(t *testing.T) {
+	// The stale Get(0) after Remove(0) is the violation, and everything
+	// else is noise that Minimize should drop.
+	h := History{Initial: []int{5}, Operations: []Operation{
+		op(1, 0, 5, get(0), value(5)),
+		op(2, 0, 5, find(9), fail),
+		op(0, 1, 2, remove(0), ok),
+		op(1, 6, 7, insert(3, 1), fail),
+		op(2, 6, 9, find(5), fail),
+		op(1, 8, 9, get(0), value(5)),
+		op(0, 10, 11, insert(0, 8), ok),
+		op(2, 12, 13, get(0), value(8)),
+	}}
+
+	minimal, err := Minimize(context.Background(), h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := Check(context.Background(), minimal); ok {
+		t.Fatalf("Minimize() returned a linearizable history\n%v", minimal)
+	}
+	// Nothing is in flight after Remove(0) returns, so the operations up
+	// to there become the empty list they leave.
+	want := op(1, 8, 9, get(0), value(5))
+	if len(minimal.Initial) != 0 || len(minimal.Operations) != 1 || minimal.Operations[0] != want {
+		t.Fatalf("Minimize() = \n%v\nexpected Get(0) -> 5 from an empty list", minimal)
+	}
+}
+
+func TestRecordListBackends(t *testing.T) {
+	backends := []string{
+		linkedlist.BackendLinkedList,
+		linkedlist.BackendDoubly,
+		linkedlist.BackendSkipList,
+		linkedlist.BackendUnrolled,
+		linkedlist.BackendTreap,
+		linkedlist.BackendLockFree,
+		linkedlist.BackendLockCoupling,
+		linkedlist.BackendPersistent,
+	}
+	for _, name := range backends {
+		t.Run(name, func(t *testing.T) {
+			l, err := linkedlist.NewBackend(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkRecord(t, NewListClient(l))
+		})
+	}
+}
+
+func TestRecordHTTP(t *testing.T) {
+	l1, _ := linkedlist.NewBackend(linkedlist.BackendLinkedList)
+	s1 := httptest.NewServer(v1.V1(l1))
+	defer s1.Close()
+	t.Run("v1", func(t *testing.T) {
+		checkRecord(t, NewV1Client(s1.URL, s1.Client()))
+	})
+
+	// v2 logs every request.
+	logger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	defer slog.SetDefault(logger)
+
+	l2, _ := linkedlist.NewBackend(linkedlist.BackendLinkedList)
+	h2, err := v2.V2(l2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2 := httptest.NewServer(h2)
+	defer s2.Close()
+	t.Run("v2", func(t *testing.T) {
+		checkRecord(t, NewV2Client(s2.URL, s2.Client()))
+	})
+}
+
+func checkRecord(t *testing.T, c Client) {
+	t.Helper()
+	cfg := Config{Clients: 4, Operations: 50, Values: 4, Seed: 1}
+	h, err := Record(context.Background(), c, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Operations) != cfg.Clients*cfg.Operations {
+		t.Fatalf("recorded %d operations, expected %d", len(h.Operations), cfg.Clients*cfg.Operations)
+	}
+	for _, op := range h.Operations {
+		if op.Pending {
+			t.Fatalf("%v failed", op)
+		}
+	}
+	ok, err := Check(context.Background(), h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		minimal, _ := Minimize(context.Background(), h)
+		t.Fatalf("history is not linearizable:\n%v", minimal)
+	}
+}
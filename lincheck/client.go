@@ -0,0 +1,254 @@
+package lincheck
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"linkedlist/api/apierr"
+	"linkedlist/linkedlist"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// listClient runs operations on a list in this process.
+type listClient struct {
+	list linkedlist.List[int]
+	// mutex guards lists that are not linkedlist.Concurrent the way v2
+	// does: writes take it exclusively and reads either way, by route.
+	mutex      sync.RWMutex
+	concurrent bool
+}
+
+// NewListClient returns a Client that calls l directly. A list that is not
+// linkedlist.Concurrent is guarded by a sync.RWMutex, as the v2 API guards
+// it.
+//
+// Get and Find take the mutex exclusively by default; the "rlock" route
+// shares it, and the "segments" and "search" routes share it and go
+// through linkedlist.SegmentSearcher.
+func NewListClient(l linkedlist.List[int]) Client {
+	_, concurrent := l.(linkedlist.Concurrent)
+	return &listClient{list: l, concurrent: concurrent}
+}
+
+func (c *listClient) Routes(k Kind) []string {
+	_, segmented := c.list.(linkedlist.SegmentSearcher[int])
+	switch {
+	case k == Get && segmented:
+		return []string{"", "rlock", "segments"}
+	case k == Find && segmented:
+		return []string{"", "rlock", "search"}
+	case k == Get || k == Find:
+		return []string{"", "rlock"}
+	default:
+		return nil
+	}
+}
+
+func (c *listClient) lock(exclusive bool) func() {
+	switch {
+	case c.concurrent:
+		return func() {}
+	case exclusive:
+		c.mutex.Lock()
+		return c.mutex.Unlock
+	default:
+		c.mutex.RLock()
+		return c.mutex.RUnlock
+	}
+}
+
+func (c *listClient) List(ctx context.Context) ([]int, error) {
+	defer c.lock(false)()
+	return linkedlist.HandleListContext(ctx, c.list)
+}
+
+func (c *listClient) Do(ctx context.Context, in Input) (Output, error) {
+	defer c.lock(in.Kind == Insert || in.Kind == Remove || in.Route == "")()
+
+	var err error
+	var out Output
+	switch in.Kind {
+	case Insert:
+		err = linkedlist.Insert(c.list, in.Index, in.Value)
+	case Remove:
+		err = linkedlist.Remove(c.list, in.Index)
+	case Get:
+		if in.Route == "segments" {
+			value, ok := c.list.(linkedlist.SegmentSearcher[int]).SearchInSegmentedNodes(ctx, int(in.Index))
+			out.Value, err = value, errorIf(!ok, linkedlist.ErrIndexOutOfRange)
+			break
+		}
+		out.Value, err = linkedlist.GetContext(ctx, c.list, in.Index)
+	case Find:
+		if in.Route == "search" {
+			out.Index, err = linkedlist.Search(ctx, c.list, in.Value)
+			break
+		}
+		out.Index, err = linkedlist.FindContext(ctx, c.list, in.Value)
+	default:
+		return out, fmt.Errorf("unknown operation %q", in.Kind)
+	}
+	return result(out, err)
+}
+
+func errorIf(cond bool, err error) error {
+	if cond {
+		return err
+	}
+	return nil
+}
+
+// result turns the errors that are answers of the list into OK false and
+// leaves the others, whose outcome is unknown.
+func result(out Output, err error) (Output, error) {
+	switch {
+	case err == nil:
+		out.OK = true
+		return out, nil
+	case errors.Is(err, linkedlist.ErrIndexOutOfRange), errors.Is(err, linkedlist.ErrNotFound):
+		return Output{}, nil
+	default:
+		return Output{}, err
+	}
+}
+
+// httpClient runs operations through the routes of a running server.
+type httpClient struct {
+	base   string
+	client *http.Client
+	v2     bool
+}
+
+// NewV1Client returns a Client for the /v1 routes under base, such as
+// "http://localhost:8080/v1".
+func NewV1Client(base string, client *http.Client) Client {
+	return &httpClient{base: strings.TrimSuffix(base, "/"), client: client}
+}
+
+// NewV2Client returns a Client for the /v2 routes under base, such as
+// "http://localhost:8080/v2". Get and Find run through the route of v2 that
+// locks exclusively, the one that shares the lock and the one that searches
+// the segments concurrently.
+func NewV2Client(base string, client *http.Client) Client {
+	return &httpClient{base: strings.TrimSuffix(base, "/"), client: client, v2: true}
+}
+
+func (c *httpClient) Routes(k Kind) []string {
+	switch {
+	case !c.v2:
+		return nil
+	case k == Get:
+		return []string{"index", "rwmutex/index", "concurrency/index"}
+	case k == Find:
+		return []string{"value", "rwmutex/value", "concurrency/value"}
+	default:
+		return nil
+	}
+}
+
+// List reads /v1/list, or every index of v2 until the first one out of
+// range since v2 has no route for the whole list.
+func (c *httpClient) List(ctx context.Context) ([]int, error) {
+	if !c.v2 {
+		var values []int
+		if _, err := c.call(ctx, http.MethodGet, "/list", nil, &values); err != nil {
+			return nil, err
+		}
+		return values, nil
+	}
+
+	values := []int{}
+	for {
+		out, err := c.Do(ctx, Input{Kind: Get, Index: uint(len(values)), Route: "index"})
+		if err != nil {
+			return nil, err
+		}
+		if !out.OK {
+			return values, nil
+		}
+		values = append(values, out.Value)
+	}
+}
+
+func (c *httpClient) Do(ctx context.Context, in Input) (Output, error) {
+	var out Output
+	var ok bool
+	var err error
+	switch {
+	case in.Kind == Insert && c.v2:
+		ok, err = c.call(ctx, http.MethodPost, fmt.Sprintf("/numbers/%d/%d", in.Index, in.Value), nil, nil)
+	case in.Kind == Insert:
+		body := map[string]any{"index": in.Index, "value": in.Value}
+		ok, err = c.call(ctx, http.MethodPost, "/insert", body, nil)
+	case in.Kind == Remove && c.v2:
+		ok, err = c.call(ctx, http.MethodDelete, fmt.Sprintf("/numbers/%d", in.Index), nil, nil)
+	case in.Kind == Remove:
+		ok, err = c.call(ctx, http.MethodDelete, fmt.Sprintf("/remove/%d", in.Index), nil, nil)
+	case in.Kind == Get && c.v2:
+		ok, err = c.call(ctx, http.MethodGet, fmt.Sprintf("/numbers/%s/%d", route(in, "index"), in.Index), nil, &out)
+	case in.Kind == Get:
+		ok, err = c.call(ctx, http.MethodGet, fmt.Sprintf("/get/%d", in.Index), nil, &out)
+	case in.Kind == Find && c.v2:
+		ok, err = c.call(ctx, http.MethodGet, fmt.Sprintf("/numbers/%s/%d", route(in, "value"), in.Value), nil, &out)
+	case in.Kind == Find:
+		ok, err = c.call(ctx, http.MethodGet, fmt.Sprintf("/find/%d", in.Value), nil, &out)
+	default:
+		return out, fmt.Errorf("unknown operation %q", in.Kind)
+	}
+	if err != nil || !ok {
+		return Output{}, err
+	}
+	out.OK = true
+	return out, nil
+}
+
+func route(in Input, fallback string) string {
+	if in.Route == "" {
+		return fallback
+	}
+	return in.Route
+}
+
+// call sends a request and decodes a successful answer into out. It
+// reports false for the answers that an index is out of range or a value
+// not found, and an error for any other failure.
+func (c *httpClient) call(ctx context.Context, method, path string, body, out any) (bool, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return false, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.base+path, reader)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(resp.Body)
+		var answer apierr.Error
+		if resp.StatusCode == http.StatusNotFound && json.Unmarshal(msg, &answer) == nil &&
+			(answer.Code == "index_out_of_range" || answer.Code == "value_not_found") {
+			return false, nil
+		}
+		return false, fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, bytes.TrimSpace(msg))
+	}
+	if out != nil {
+		return true, json.NewDecoder(resp.Body).Decode(out)
+	}
+	return true, nil
+}
@@ -0,0 +1,214 @@
+// Package lincheck records concurrent histories of list operations and
+// checks that they are linearizable: that every operation appears to take
+// effect at a single instant between its call and its return, in an order
+// a sequential list would agree with.
+package lincheck
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Kind is the list operation an Input calls.
+type Kind string
+
+const (
+	Insert Kind = "insert"
+	Remove Kind = "remove"
+	Get    Kind = "get"
+	Find   Kind = "find"
+)
+
+// Input is an operation and its arguments. Route names the way a client
+// ran it, such as the HTTP route, and does not change what it must return.
+type Input struct {
+	Kind  Kind   `json:"kind"`
+	Index uint   `json:"index,omitempty"`
+	Value int    `json:"value,omitempty"`
+	Route string `json:"route,omitempty"`
+}
+
+func (in Input) String() string {
+	var s string
+	switch in.Kind {
+	case Insert:
+		s = fmt.Sprintf("Insert(%d, %d)", in.Index, in.Value)
+	case Remove:
+		s = fmt.Sprintf("Remove(%d)", in.Index)
+	case Get:
+		s = fmt.Sprintf("Get(%d)", in.Index)
+	case Find:
+		s = fmt.Sprintf("Find(%d)", in.Value)
+	default:
+		s = string(in.Kind)
+	}
+	if in.Route != "" {
+		s += " via " + in.Route
+	}
+	return s
+}
+
+// Output is what an operation returned. OK is false for an index out of
+// range or a value not found; Value is the result of Get and Index the
+// result of Find.
+type Output struct {
+	OK    bool `json:"ok"`
+	Value int  `json:"value,omitempty"`
+	Index uint `json:"index,omitempty"`
+}
+
+// Operation is one call of a history. Call and Return are the nanoseconds
+// since the history started. An operation is Pending when its client never
+// learned its outcome, because of a timeout or a server error: it may or
+// may not have taken effect, and Output means nothing.
+type Operation struct {
+	Client  int    `json:"client"`
+	Input   Input  `json:"input"`
+	Output  Output `json:"output"`
+	Call    int64  `json:"call"`
+	Return  int64  `json:"return"`
+	Pending bool   `json:"pending,omitempty"`
+}
+
+func (op Operation) String() string {
+	ret := "pending"
+	if !op.Pending {
+		ret = time.Duration(op.Return).String()
+	}
+	return fmt.Sprintf("client %d [%v, %s] %v -> %s", op.Client, time.Duration(op.Call), ret, op.Input, op.result())
+}
+
+func (op Operation) result() string {
+	switch {
+	case op.Pending:
+		return "?"
+	case !op.Output.OK:
+		return "false"
+	case op.Input.Kind == Get:
+		return fmt.Sprint(op.Output.Value)
+	case op.Input.Kind == Find:
+		return fmt.Sprint(op.Output.Index)
+	default:
+		return "true"
+	}
+}
+
+// end returns the time by which op took effect, which is never for a
+// pending operation.
+func (op Operation) end() int64 {
+	if op.Pending {
+		return math.MaxInt64
+	}
+	return op.Return
+}
+
+// History is the operations of a run together with the list they started
+// from.
+type History struct {
+	Initial    []int       `json:"initial"`
+	Operations []Operation `json:"operations"`
+}
+
+func (h History) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "initial list %v\n", h.Initial)
+	ops := slices.Clone(h.Operations)
+	slices.SortFunc(ops, func(a, b Operation) int { return int(a.Call - b.Call) })
+	for _, op := range ops {
+		fmt.Fprintf(&b, "  %v\n", op)
+	}
+	return b.String()
+}
+
+// Client runs operations against a list.
+type Client interface {
+	// List returns the current values. Record calls it once, before the
+	// history starts.
+	List(ctx context.Context) ([]int, error)
+	// Do runs in and returns its output. An error means the outcome is
+	// unknown and the operation is recorded as pending.
+	Do(ctx context.Context, in Input) (Output, error)
+	// Routes returns the ways the client can run operations of kind k,
+	// which Record picks from at random. Nil means a single way.
+	Routes(k Kind) []string
+}
+
+// Config sizes the history Record runs.
+type Config struct {
+	// Clients is the number of goroutines that call the list at once.
+	Clients int
+	// Operations is the number of operations of each client.
+	Operations int
+	// Values bounds the values and indexes of the operations. Small bounds
+	// make the clients meet on the same elements.
+	Values int
+	// Seed makes the operations of a run repeatable.
+	Seed uint64
+}
+
+// Record runs cfg.Clients clients against c at once and returns the history
+// of their operations. It stops early, with the operations run so far, once
+// ctx is done.
+func Record(ctx context.Context, c Client, cfg Config) (History, error) {
+	initial, err := c.List(ctx)
+	if err != nil {
+		return History{}, err
+	}
+
+	var (
+		mu  sync.Mutex
+		ops []Operation
+		wg  sync.WaitGroup
+	)
+	start := time.Now()
+	for client := range cfg.Clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rng := rand.New(rand.NewPCG(cfg.Seed, uint64(client)))
+			for range cfg.Operations {
+				if ctx.Err() != nil {
+					return
+				}
+				in := randomInput(rng, c, cfg.Values)
+				op := Operation{Client: client, Input: in, Call: int64(time.Since(start))}
+				out, err := c.Do(ctx, in)
+				op.Return = int64(time.Since(start))
+				op.Output, op.Pending = out, err != nil
+
+				mu.Lock()
+				ops = append(ops, op)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return History{Initial: initial, Operations: ops}, nil
+}
+
+// randomInput returns an operation with half of them writes, so that the
+// list keeps about the same length.
+func randomInput(rng *rand.Rand, c Client, values int) Input {
+	values = max(values, 1)
+	var in Input
+	switch rng.IntN(4) {
+	case 0:
+		in = Input{Kind: Insert, Index: uint(rng.IntN(values)), Value: rng.IntN(values)}
+	case 1:
+		in = Input{Kind: Remove, Index: uint(rng.IntN(values))}
+	case 2:
+		in = Input{Kind: Get, Index: uint(rng.IntN(values))}
+	default:
+		in = Input{Kind: Find, Value: rng.IntN(values)}
+	}
+	if routes := c.Routes(in.Kind); len(routes) > 0 {
+		in.Route = routes[rng.IntN(len(routes))]
+	}
+	return in
+}
@@ -0,0 +1,451 @@
+package linkedlist
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+const benchmarkSize = 100_000
+
+// newBenchmarkList links n nodes directly, since filling a list without a
+// useful cache through Insert is quadratic.
+func newBenchmarkList(n int, opts ...Option) *LinkedList[int] {
+	l := New[int](opts...)
+	var tail *Node[int]
+	for i := 0; i < n; i++ {
+		node := &Node[int]{Value: i}
+		if tail == nil {
+			l.head = node
+		} else {
+			tail.Next = node
+		}
+		tail = node
+	}
+	l.length = uint(n)
+	if !l.fixedPart {
+		l.part = segmentSizeFor(l.length)
+	}
+	l.rebuildCache()
+	return l
+}
+
+// benchmarkSegmentSizes compares fixed caches with a single segment, which
+// makes every seek walk from head, and with the adaptive size (0).
+var benchmarkSegmentSizes = []uint{defaultPart, 100, benchmarkSize, 0}
+
+func BenchmarkLinkedListGet(b *testing.B) {
+	for _, part := range benchmarkSegmentSizes {
+		b.Run(segmentName(part), func(b *testing.B) {
+			l := newBenchmarkList(benchmarkSize, WithSegmentSize(part))
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				l.Get(uint(r.Intn(benchmarkSize)))
+			}
+		})
+	}
+}
+
+func BenchmarkLinkedListAppend(b *testing.B) {
+	for _, part := range benchmarkSegmentSizes {
+		b.Run(segmentName(part), func(b *testing.B) {
+			l := newBenchmarkList(benchmarkSize, WithSegmentSize(part))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				l.Insert(l.length, i)
+				l.Remove(l.length - 1)
+			}
+		})
+	}
+}
+
+func BenchmarkLinkedListInsertRemove(b *testing.B) {
+	for _, part := range benchmarkSegmentSizes {
+		b.Run(segmentName(part), func(b *testing.B) {
+			l := newBenchmarkList(benchmarkSize, WithSegmentSize(part))
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				index := uint(r.Intn(benchmarkSize))
+				l.Insert(index, i)
+				l.Remove(index)
+			}
+		})
+	}
+}
+
+// BenchmarkLinkedListInsert grows a 100k element list at random indexes,
+// which only shifts the segment starts after the insert.
+func BenchmarkLinkedListInsert(b *testing.B) {
+	for _, part := range benchmarkSegmentSizes {
+		b.Run(segmentName(part), func(b *testing.B) {
+			l := newBenchmarkList(benchmarkSize, WithSegmentSize(part))
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				l.Insert(uint(r.Intn(int(l.length)+1)), i)
+			}
+		})
+	}
+}
+
+func segmentName(part uint) string {
+	if part == 0 {
+		return "segment=adaptive"
+	}
+	return "segment=" + strconv.Itoa(int(part))
+}
+
+func newBenchmarkDoublyList(n int, opts ...Option) *DoublyLinkedList[int] {
+	l := NewDoubly[int](opts...)
+	for i := 0; i < n; i++ {
+		l.PushBack(i)
+	}
+	return l
+}
+
+func BenchmarkDoublyLinkedListGet(b *testing.B) {
+	l := newBenchmarkDoublyList(benchmarkSize)
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Get(uint(r.Intn(benchmarkSize)))
+	}
+}
+
+func BenchmarkDoublyLinkedListInsertRemove(b *testing.B) {
+	l := newBenchmarkDoublyList(benchmarkSize)
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		index := uint(r.Intn(benchmarkSize))
+		l.Insert(index, i)
+		l.Remove(index)
+	}
+}
+
+// BenchmarkDoublyLinkedListFront pushes and pops at the head, which only
+// changes the nodes before the first cached segment.
+func BenchmarkDoublyLinkedListFront(b *testing.B) {
+	for _, n := range []int{1_000, benchmarkSize} {
+		b.Run("n="+strconv.Itoa(n), benchmarkDoublyFront(n))
+	}
+}
+
+func benchmarkDoublyFront(n int) func(b *testing.B) {
+	return func(b *testing.B) {
+		l := newBenchmarkDoublyList(n)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			l.PushFront(i)
+			l.PopFront()
+		}
+	}
+}
+
+func newBenchmarkSkipList(n int) *SkipList[int] {
+	l := NewSkipList[int]()
+	for i := 0; i < n; i++ {
+		l.Insert(uint(i), i)
+	}
+	return l
+}
+
+func BenchmarkSkipListGet(b *testing.B) {
+	l := newBenchmarkSkipList(benchmarkSize)
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Get(uint(r.Intn(benchmarkSize)))
+	}
+}
+
+func BenchmarkSkipListInsertRemove(b *testing.B) {
+	l := newBenchmarkSkipList(benchmarkSize)
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		index := uint(r.Intn(benchmarkSize))
+		l.Insert(index, i)
+		l.Remove(index)
+	}
+}
+
+func newBenchmarkUnrolledList(n int) *UnrolledList[int] {
+	l := NewUnrolled[int]()
+	for i := 0; i < n; i++ {
+		l.Insert(uint(i), i)
+	}
+	return l
+}
+
+func BenchmarkUnrolledListGet(b *testing.B) {
+	l := newBenchmarkUnrolledList(benchmarkSize)
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Get(uint(r.Intn(benchmarkSize)))
+	}
+}
+
+func BenchmarkUnrolledListInsertRemove(b *testing.B) {
+	l := newBenchmarkUnrolledList(benchmarkSize)
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		index := uint(r.Intn(benchmarkSize))
+		l.Insert(index, i)
+		l.Remove(index)
+	}
+}
+
+func BenchmarkUnrolledListFind(b *testing.B) {
+	l := newBenchmarkUnrolledList(benchmarkSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Find(-1)
+	}
+}
+
+func BenchmarkLinkedListFind(b *testing.B) {
+	l := newBenchmarkList(benchmarkSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Find(-1)
+	}
+}
+
+func BenchmarkLinkedListFindFunc(b *testing.B) {
+	l := newBenchmarkList(benchmarkSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.FindFunc(func(v int) bool { return v < 0 })
+	}
+}
+
+func BenchmarkLinkedListFindFuncConcurrently(b *testing.B) {
+	l := newBenchmarkList(benchmarkSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.FindFuncConcurrently(context.Background(), func(v int) bool { return v < 0 })
+	}
+}
+
+// newBenchmarkIndexedList fills a list with a value index through Append,
+// which labels and indexes every node.
+func newBenchmarkIndexedList(n int) *LinkedList[int] {
+	l := New[int](WithValueIndex())
+	values := make([]int, n)
+	for i := range values {
+		values[i] = i
+	}
+	l.Append(values...)
+	return l
+}
+
+func BenchmarkLinkedListFindIndexed(b *testing.B) {
+	l := newBenchmarkIndexedList(benchmarkSize)
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Find(r.Intn(benchmarkSize))
+	}
+}
+
+// BenchmarkLinkedListFindFiltered looks up absent values, which the Bloom
+// filter answers without scanning, like BenchmarkLinkedListFind.
+func BenchmarkLinkedListFindFiltered(b *testing.B) {
+	l := New[int](WithBloomFilter(benchmarkSize, 0.01))
+	values := make([]int, benchmarkSize)
+	for i := range values {
+		values[i] = i
+	}
+	l.Append(values...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Find(-1 - i)
+	}
+}
+
+func BenchmarkLinkedListInsertRemoveIndexed(b *testing.B) {
+	l := newBenchmarkIndexedList(benchmarkSize)
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		index := uint(r.Intn(benchmarkSize))
+		l.Insert(index, i)
+		l.Remove(index)
+	}
+}
+
+func newBenchmarkTreap(n int) *Treap[int] {
+	l := NewTreap[int]()
+	for i := 0; i < n; i++ {
+		l.Insert(uint(i), i)
+	}
+	return l
+}
+
+func BenchmarkTreapGet(b *testing.B) {
+	l := newBenchmarkTreap(benchmarkSize)
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Get(uint(r.Intn(benchmarkSize)))
+	}
+}
+
+func BenchmarkTreapInsertRemove(b *testing.B) {
+	l := newBenchmarkTreap(benchmarkSize)
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		index := uint(r.Intn(benchmarkSize))
+		l.Insert(index, i)
+		l.Remove(index)
+	}
+}
+
+func newBenchmarkPersistent(n int) *PersistentList[int] {
+	l := NewPersistent[int]()
+	values := make([]int, n)
+	for i := range values {
+		values[i] = i
+	}
+	l.Append(values...)
+	return l
+}
+
+func BenchmarkPersistentGet(b *testing.B) {
+	l := newBenchmarkPersistent(benchmarkSize)
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Get(uint(r.Intn(benchmarkSize)))
+	}
+}
+
+func BenchmarkPersistentInsertRemove(b *testing.B) {
+	l := newBenchmarkPersistent(benchmarkSize)
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		index := uint(r.Intn(benchmarkSize))
+		l.Insert(index, i)
+		l.Remove(index)
+	}
+}
+
+// The parallel benchmarks run a queue-like mix of appends, front removals
+// and reads near the front from every goroutine, once through a mutex
+// around a LinkedList as both APIs do and once on the lock-free list.
+const parallelBenchmarkSize = 1000
+
+func BenchmarkMutexLinkedListParallel(b *testing.B) {
+	l := newBenchmarkList(parallelBenchmarkSize)
+	var mutex sync.Mutex
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			mutex.Lock()
+			switch i % 3 {
+			case 0:
+				l.Append(i)
+			case 1:
+				l.Remove(0)
+			case 2:
+				l.Get(uint(i % 100))
+			}
+			mutex.Unlock()
+		}
+	})
+}
+
+func BenchmarkLockFreeParallel(b *testing.B) {
+	l := NewLockFree[int]()
+	for i := 0; i < parallelBenchmarkSize; i++ {
+		l.Append(i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			switch i % 3 {
+			case 0:
+				l.Append(i)
+			case 1:
+				l.Remove(0)
+			case 2:
+				l.Get(uint(i % 100))
+			}
+		}
+	})
+}
+
+// The read-mostly benchmarks mirror the v2 Find and RWMutexFind routes:
+// every goroutine looks values up and one operation in ten inserts or
+// removes at a random index.
+const readMostlySize = 10_000
+
+func benchmarkReadMostly(b *testing.B, find func(v int), insert func(index uint, v int), remove func(index uint)) {
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(1))
+		for i := 0; pb.Next(); i++ {
+			switch i % 10 {
+			case 0:
+				insert(uint(r.Intn(readMostlySize)), i)
+			case 5:
+				remove(uint(r.Intn(readMostlySize)))
+			default:
+				find(r.Intn(readMostlySize))
+			}
+		}
+	})
+}
+
+func BenchmarkMutexFindParallel(b *testing.B) {
+	l := newBenchmarkList(readMostlySize)
+	var mutex sync.Mutex
+	b.ResetTimer()
+	benchmarkReadMostly(b,
+		func(v int) { mutex.Lock(); l.Find(v); mutex.Unlock() },
+		func(index uint, v int) { mutex.Lock(); l.Insert(index, v); mutex.Unlock() },
+		func(index uint) { mutex.Lock(); l.Remove(index); mutex.Unlock() },
+	)
+}
+
+func BenchmarkRWMutexFindParallel(b *testing.B) {
+	l := newBenchmarkList(readMostlySize)
+	var mutex sync.RWMutex
+	b.ResetTimer()
+	benchmarkReadMostly(b,
+		func(v int) { mutex.RLock(); l.Find(v); mutex.RUnlock() },
+		func(index uint, v int) { mutex.Lock(); l.Insert(index, v); mutex.Unlock() },
+		func(index uint) { mutex.Lock(); l.Remove(index); mutex.Unlock() },
+	)
+}
+
+func BenchmarkLockCouplingFindParallel(b *testing.B) {
+	l := NewLockCoupling[int]()
+	for i := 0; i < readMostlySize; i++ {
+		l.Insert(uint(i), i)
+	}
+	b.ResetTimer()
+	benchmarkReadMostly(b,
+		func(v int) { l.Find(v) },
+		func(index uint, v int) { l.Insert(index, v) },
+		func(index uint) { l.Remove(index) },
+	)
+}
+
+func BenchmarkPersistentFindParallel(b *testing.B) {
+	l := newBenchmarkPersistent(readMostlySize)
+	b.ResetTimer()
+	benchmarkReadMostly(b,
+		func(v int) { l.Snapshot().Find(v) },
+		func(index uint, v int) { l.Insert(index, v) },
+		func(index uint) { l.Remove(index) },
+	)
+}
@@ -0,0 +1,178 @@
+package linkedlist
+
+import (
+	"hash/maphash"
+	"iter"
+	"math"
+	"sync/atomic"
+)
+
+// bloomFilter is a counting Bloom filter: every value bumps k counters and
+// removing it lowers them again. A value with any counter at zero is
+// certainly absent. The counters are atomic so that lookups need no lock,
+// even while a writer updates the filter, and a rebuild fills a new set of
+// counters and swaps it in, so lookups never see it half filled.
+type bloomFilter[T any] struct {
+	counters atomic.Pointer[[]atomic.Uint32]
+	hashes   uint
+	hash     func(T) uint64
+}
+
+// WithBloomFilter keeps a counting Bloom filter of the values, sized so
+// that about falsePositiveRate of the lookups for absent values still scan
+// the list once it holds capacity elements. Find and SearchConcurrently
+// then return misses without scanning. Like WithValueIndex, it applies to
+// lists created by New.
+func WithBloomFilter(capacity uint, falsePositiveRate float64) Option {
+	return func(o *options) {
+		o.bloomCapacity = capacity
+		o.bloomRate = falsePositiveRate
+	}
+}
+
+// newBloomFilter returns a filter with the optimal number of counters and
+// hash functions for capacity values at falsePositiveRate.
+func newBloomFilter[T comparable](capacity uint, falsePositiveRate float64) *bloomFilter[T] {
+	n := float64(max(capacity, 1))
+	p := min(max(falsePositiveRate, 1e-9), 0.5)
+	m := math.Ceil(-n * math.Log(p) / (math.Ln2 * math.Ln2))
+	k := max(math.Round(m/n*math.Ln2), 1)
+
+	seed := maphash.MakeSeed()
+	f := &bloomFilter[T]{
+		hashes: uint(k),
+		hash:   func(v T) uint64 { return maphash.Comparable(seed, v) },
+	}
+	f.store(make([]atomic.Uint32, uint(m)))
+	return f
+}
+
+// empty returns a filter with the same size and hash and no values.
+func (f *bloomFilter[T]) empty() *bloomFilter[T] {
+	if f == nil {
+		return nil
+	}
+	e := &bloomFilter[T]{hashes: f.hashes, hash: f.hash}
+	e.store(make([]atomic.Uint32, f.size()))
+	return e
+}
+
+func (f *bloomFilter[T]) load() []atomic.Uint32 {
+	return *f.counters.Load()
+}
+
+func (f *bloomFilter[T]) store(counters []atomic.Uint32) {
+	f.counters.Store(&counters)
+}
+
+func (f *bloomFilter[T]) size() uint {
+	return uint(len(f.load()))
+}
+
+// slots yields the counters of v among counters by double hashing one
+// 64-bit hash.
+func (f *bloomFilter[T]) slots(counters []atomic.Uint32, v T) iter.Seq[*atomic.Uint32] {
+	return func(yield func(*atomic.Uint32) bool) {
+		h := f.hash(v)
+		h1, h2 := h&math.MaxUint32, h>>32|1
+		m := uint64(len(counters))
+		for i := range uint64(f.hashes) {
+			if !yield(&counters[(h1+i*h2)%m]) {
+				return
+			}
+		}
+	}
+}
+
+func (f *bloomFilter[T]) add(v T) {
+	if f == nil || !selfEqual(v) {
+		return
+	}
+	for counter := range f.slots(f.load(), v) {
+		counter.Add(1)
+	}
+}
+
+// remove lowers the counters of v, but never below zero: a counter that
+// wrapped around would report values as present, and one lowered for a
+// value that was never added would hide values that are.
+func (f *bloomFilter[T]) remove(v T) {
+	if f == nil || !selfEqual(v) {
+		return
+	}
+	for counter := range f.slots(f.load(), v) {
+		for {
+			n := counter.Load()
+			if n == 0 || counter.CompareAndSwap(n, n-1) {
+				break
+			}
+		}
+	}
+}
+
+func (f *bloomFilter[T]) mayContain(v T) bool {
+	if f == nil || !selfEqual(v) {
+		return true
+	}
+	for counter := range f.slots(f.load(), v) {
+		if counter.Load() == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// reset swaps in zeroed counters.
+func (f *bloomFilter[T]) reset() {
+	if f == nil {
+		return
+	}
+	f.store(make([]atomic.Uint32, f.size()))
+}
+
+// refilter rebuilds the filter from the nodes of the list. It fills new
+// counters before swapping them in, so a lookup running without the list
+// lock meanwhile still sees every value in the old ones.
+func (l *LinkedList[T]) refilter() {
+	if l.filter == nil {
+		return
+	}
+	counters := make([]atomic.Uint32, l.filter.size())
+	for current := l.head; current != nil; current = current.Next {
+		if !selfEqual(current.Value) {
+			continue
+		}
+		for counter := range l.filter.slots(counters, current.Value) {
+			counter.Add(1)
+		}
+	}
+	l.filter.store(counters)
+}
+
+// MayContain reports false if val is certainly not in the list. It always
+// reports true for a list without a Bloom filter. Unlike the other
+// methods, it is safe to call concurrently with modifications.
+func (l *LinkedList[T]) MayContain(val T) bool {
+	return l.filter.mayContain(val)
+}
+
+// FilterSize returns the number of counters in the Bloom filter, or 0 for
+// a list without one.
+func (l *LinkedList[T]) FilterSize() uint {
+	if l.filter == nil {
+		return 0
+	}
+	return l.filter.size()
+}
+
+// FalsePositiveRate estimates the share of lookups for absent values that
+// the Bloom filter lets through at the current length. It is 1 for a list
+// without a filter.
+func (l *LinkedList[T]) FalsePositiveRate() float64 {
+	if l.filter == nil {
+		return 1
+	}
+	k := float64(l.filter.hashes)
+	m := float64(l.filter.size())
+	return math.Pow(1-math.Exp(-k*float64(l.length)/m), k)
+}
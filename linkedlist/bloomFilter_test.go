@@ -0,0 +1,174 @@
+package linkedlist
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"testing"
+	"testing/quick"
+)
+
+func TestBloomFilterQuick(t *testing.T) {
+	err := quick.Check(func(ops []uint16) bool {
+		l := New[int](WithBloomFilter(64, 0.01), WithSegmentSize(4))
+		var model []int
+
+		for k, op := range ops {
+			switch {
+			case op%5 == 0 && len(model) > 0:
+				index := uint(op) % uint(len(model))
+				l.Remove(index)
+				model = slices.Delete(model, int(index), int(index)+1)
+			case op%5 == 1 && len(model) > 0:
+				from := uint(op) % uint(len(model))
+				to := min(from+3, uint(len(model)))
+				l.RemoveRange(from, to)
+				model = slices.Delete(model, int(from), int(to))
+			case op%5 == 2:
+				other := New[int](WithBloomFilter(8, 0.01))
+				other.Append(k, k+1000)
+				index := uint(op) % uint(len(model)+1)
+				l.Splice(index, other)
+				model = slices.Insert(model, int(index), k, k+1000)
+				if other.MayContain(k) {
+					return false
+				}
+			case op%5 == 3:
+				front, back := l.Split(uint(op) % uint(len(model)+1))
+				front.Concat(back)
+				l = front
+			default:
+				index := uint(op) % uint(len(model)+1)
+				l.InsertAll(index, []int{k})
+				model = slices.Insert(model, int(index), k)
+			}
+		}
+
+		for _, v := range model {
+			if !l.MayContain(v) {
+				return false
+			}
+			if index, found := l.Find(v); !found || index != uint(slices.Index(model, v)) {
+				return false
+			}
+		}
+
+		// Once every value is gone, every counter is back to zero.
+		l.RemoveRange(0, l.Len())
+		for k := range ops {
+			if l.MayContain(k) || l.MayContain(k+1000) {
+				return false
+			}
+		}
+		return true
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBloomFilterFalsePositiveRate(t *testing.T) {
+	const capacity = 10_000
+	l := New[int](WithBloomFilter(capacity, 0.01))
+	for i := range capacity {
+		l.Insert(l.Len(), i)
+	}
+
+	passed := 0
+	for i := capacity; i < 2*capacity; i++ {
+		if l.MayContain(i) {
+			passed++
+		}
+	}
+	if rate := float64(passed) / capacity; rate > 0.02 {
+		t.Errorf("expected a false positive rate near 0.01, measured %f", rate)
+	}
+	if rate := l.FalsePositiveRate(); rate < 0.005 || rate > 0.02 {
+		t.Errorf("expected an estimated false positive rate near 0.01, got %f", rate)
+	}
+	if l.FilterSize() == 0 || New[int]().FilterSize() != 0 {
+		t.Errorf("unexpected filter sizes %d and %d", l.FilterSize(), New[int]().FilterSize())
+	}
+}
+
+// TestBloomFilterNaN checks that inserting and removing NaN, which hashes
+// differently every time, leaves the filter without false negatives.
+func TestBloomFilterNaN(t *testing.T) {
+	l := New[float64](WithBloomFilter(64, 0.01))
+	for i := range 64 {
+		l.Append(float64(i) + 0.5)
+	}
+	for range 1000 {
+		l.Insert(0, math.NaN())
+		l.Remove(0)
+	}
+
+	for i := range 64 {
+		v := float64(i) + 0.5
+		if index, found := l.Find(v); !l.MayContain(v) || !found || index != uint(i) {
+			t.Fatalf("Find(%v): expected index %d, got index %d, found %t", v, i, index, found)
+		}
+	}
+
+	// Removing a value that was never added lowers no counter below zero.
+	f := newBloomFilter[int](64, 0.01)
+	f.remove(1)
+	f.add(1)
+	if !f.mayContain(1) {
+		t.Error("removing an absent value hid a value added afterwards")
+	}
+}
+
+// TestBloomFilterConcurrentLookups checks MayContain against one writer
+// without a lock. Run it with -race.
+func TestBloomFilterConcurrentLookups(t *testing.T) {
+	l := New[int](WithBloomFilter(1000, 0.01))
+	l.Append(-1)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range 1000 {
+			l.Insert(0, i)
+			if i%2 == 0 {
+				l.Remove(0)
+			}
+		}
+	}()
+	for range 1000 {
+		if !l.MayContain(-1) {
+			t.Fatal("MayContain missed a value that stays in the list")
+		}
+	}
+	wg.Wait()
+}
+
+// TestBloomFilterLookupsDuringRebuild checks that MayContain without a lock
+// keeps finding a value while RebuildIndex refills the filter.
+func TestBloomFilterLookupsDuringRebuild(t *testing.T) {
+	l := New[int](WithBloomFilter(1000, 0.01))
+	for i := range 1000 {
+		l.Append(i)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 100 {
+			l.RebuildIndex()
+		}
+	}()
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		if !l.MayContain(999) {
+			<-done
+			t.Fatal("MayContain missed a value while the filter was rebuilt")
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package linkedlist
+
+// InsertAll inserts values so that the first one ends up at index. It
+// walks to index once and refreshes the segment cache once. It inserts
+// nothing if the values would exceed the capacity.
+func (l *LinkedList[T]) InsertAll(index uint, values []T) bool {
+	if index > l.length || !l.fits(uint(len(values))) {
+		return false
+	}
+	if len(values) == 0 {
+		return true
+	}
+
+	first := &Node[T]{Value: values[0]}
+	last := first
+	for _, val := range values[1:] {
+		last.Next = &Node[T]{Value: val}
+		last = last.Next
+	}
+
+	var prev *Node[T]
+	if index == 0 {
+		last.Next = l.head
+		l.head = first
+		l.checkOrder(first, last)
+	} else {
+		prev = l.seek(index - 1)
+		last.Next = prev.Next
+		prev.Next = first
+		l.checkOrder(prev, last)
+	}
+	l.length += uint(len(values))
+	l.indexInserted(prev, first, uint(len(values)))
+	for _, val := range values {
+		l.filter.add(val)
+	}
+
+	if !l.resizeSegments() {
+		l.refreshCacheFrom(index, first)
+	}
+	return true
+}
+
+// Append inserts values at the end of the list, or none of them if they
+// would exceed the capacity.
+func (l *LinkedList[T]) Append(values ...T) {
+	l.InsertAll(l.length, values)
+}
+
+// RemoveRange removes the elements in [from, to).
+func (l *LinkedList[T]) RemoveRange(from, to uint) bool {
+	if from > to || to > l.length {
+		return false
+	}
+	if from == to {
+		return true
+	}
+
+	var after *Node[T]
+	if to < l.length {
+		after = l.seek(to)
+	}
+
+	var prev *Node[T]
+	first := l.head
+	if from > 0 {
+		prev = l.seek(from - 1)
+		first = prev.Next
+	}
+	for current := first; current != after; current = current.Next {
+		l.indexRemoved(current)
+		l.filter.remove(current.Value)
+	}
+
+	if prev == nil {
+		l.head = after
+	} else {
+		prev.Next = after
+	}
+	l.length -= to - from
+
+	if !l.resizeSegments() {
+		l.refreshCacheFrom(from, after)
+	}
+	return true
+}
+
+// Slice returns a copy of the values in [from, to).
+func (l *LinkedList[T]) Slice(from, to uint) ([]T, bool) {
+	if from > to || to > l.length {
+		return nil, false
+	}
+
+	values := make([]T, 0, to-from)
+	if from == to {
+		return values, true
+	}
+
+	current := l.seek(from)
+	for i := from; i < to; i++ {
+		values = append(values, current.Value)
+		current = current.Next
+	}
+	return values, true
+}
+
+// InsertAll inserts values so that the first one ends up at index. It
+// walks to index once and refreshes the segment cache once.
+func (l *DoublyLinkedList[T]) InsertAll(index uint, values []T) bool {
+	if index > l.length {
+		return false
+	}
+	if len(values) == 0 {
+		return true
+	}
+
+	var next *DoublyNode[T]
+	if index < l.length {
+		next = l.seek(index)
+	}
+
+	var first *DoublyNode[T]
+	for _, val := range values {
+		node := &DoublyNode[T]{Value: val}
+		l.linkBefore(node, next)
+		if first == nil {
+			first = node
+		}
+	}
+
+	if !l.resizeSegments() {
+		l.refreshCacheFrom(index, first)
+	}
+	return true
+}
+
+// Append inserts values at the end of the list.
+func (l *DoublyLinkedList[T]) Append(values ...T) {
+	l.InsertAll(l.length, values)
+}
+
+// RemoveRange removes the elements in [from, to).
+func (l *DoublyLinkedList[T]) RemoveRange(from, to uint) bool {
+	if from > to || to > l.length {
+		return false
+	}
+	if from == to {
+		return true
+	}
+
+	first := l.seek(from)
+	var after *DoublyNode[T]
+	if to < l.length {
+		after = l.seek(to)
+	}
+
+	if first.Prev == nil {
+		l.head = after
+	} else {
+		first.Prev.Next = after
+	}
+	if after == nil {
+		l.tail = first.Prev
+	} else {
+		after.Prev = first.Prev
+	}
+	l.length -= to - from
+
+	if !l.resizeSegments() {
+		l.refreshCacheFrom(from, after)
+	}
+	return true
+}
+
+// Slice returns a copy of the values in [from, to).
+func (l *DoublyLinkedList[T]) Slice(from, to uint) ([]T, bool) {
+	if from > to || to > l.length {
+		return nil, false
+	}
+
+	values := make([]T, 0, to-from)
+	if from == to {
+		return values, true
+	}
+
+	current := l.seek(from)
+	for i := from; i < to; i++ {
+		values = append(values, current.Value)
+		current = current.Next
+	}
+	return values, true
+}
@@ -0,0 +1,99 @@
+package linkedlist
+
+import (
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+func TestBulkPropertiesQuick(t *testing.T) {
+	backends := map[string]func(part uint) List[int]{
+		"linkedlist": func(part uint) List[int] { return New[int](WithSegmentSize(part)) },
+		"doubly":     func(part uint) List[int] { return NewDoubly[int](WithSegmentSize(part)) },
+		"adaptive":   func(uint) List[int] { return New[int]() },
+	}
+
+	for name, newList := range backends {
+		err := quick.Check(func(ops []uint16, values []int, part uint8) bool {
+			l := newList(uint(part%16) + 1)
+			var model []int
+
+			for k, op := range ops {
+				a := uint(op>>2) % uint(len(model)+1)
+				b := uint(op>>8) % uint(len(model)+1)
+				from, to := min(a, b), max(a, b)
+				batch := values[:k%(len(values)+1)]
+
+				switch op % 4 {
+				case 0:
+					if !InsertAll(l, a, batch) {
+						return false
+					}
+					model = slices.Insert(model, int(a), batch...)
+				case 1:
+					Append(l, batch...)
+					model = append(model, batch...)
+				case 2:
+					if !RemoveRange(l, from, to) {
+						return false
+					}
+					model = slices.Delete(model, int(from), int(to))
+				case 3:
+					out, ok := Slice(l, from, to)
+					if !ok || !slices.Equal(out, model[from:to]) {
+						return false
+					}
+				}
+
+				if l.Len() != uint(len(model)) {
+					return false
+				}
+			}
+
+			for k, v := range model {
+				if out, ok := l.Get(uint(k)); !ok || out != v {
+					return false
+				}
+			}
+			if RemoveRange(l, 1, 0) || InsertAll(l, l.Len()+1, values) {
+				return false
+			}
+			if _, ok := Slice(l, 0, l.Len()+1); ok {
+				return false
+			}
+			return slices.Equal(slices.Collect(l.(iterList).Values()), model)
+		}, nil)
+
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+}
+
+// racing is a list that another writer shrinks after every write.
+type racing struct {
+	List[int]
+}
+
+func (r racing) Insert(index uint, val int) bool {
+	defer r.List.Remove(r.Len() - 1)
+	return r.List.Insert(index, val)
+}
+
+func (r racing) Remove(index uint) bool {
+	ok := r.List.Remove(index)
+	r.List.Remove(r.Len() - 1)
+	return ok
+}
+
+func TestBulkFallbackFailsWithTheFirstWrite(t *testing.T) {
+	l := racing{NewSkipList[int]()}
+	Append[int](l.List, 1, 2, 3, 4)
+
+	if InsertAll[int](l, 4, []int{5, 6}) {
+		t.Errorf("InsertAll succeeded while the list shrank, left %v", l.HandleList())
+	}
+	if RemoveRange[int](l, 0, 3) {
+		t.Errorf("RemoveRange succeeded while the list shrank, left %v", l.HandleList())
+	}
+}
@@ -0,0 +1,47 @@
+package linkedlist_test
+
+import (
+	"testing"
+
+	"linkedlist/linkedlist"
+	"linkedlist/linkedlist/linkedlisttest"
+)
+
+func TestConformance(t *testing.T) {
+	backends := []string{
+		linkedlist.BackendLinkedList,
+		linkedlist.BackendDoubly,
+		linkedlist.BackendSkipList,
+		linkedlist.BackendUnrolled,
+		linkedlist.BackendTreap,
+		linkedlist.BackendLockFree,
+		linkedlist.BackendLockCoupling,
+		linkedlist.BackendPersistent,
+	}
+	for _, name := range backends {
+		t.Run(name, func(t *testing.T) {
+			linkedlisttest.Test(t, func() linkedlist.List[int] {
+				l, err := linkedlist.NewBackend(name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return l
+			})
+		})
+	}
+
+	// The options change how LinkedList finds and caches its nodes.
+	variants := map[string][]linkedlist.Option{
+		"segment-size-3": {linkedlist.WithSegmentSize(3)},
+		"value-index":    {linkedlist.WithValueIndex()},
+		"bloom-filter":   {linkedlist.WithBloomFilter(64, 0.01)},
+		"scan-workers-3": {linkedlist.WithSegmentSize(2), linkedlist.WithScanWorkers(3)},
+	}
+	for name, opts := range variants {
+		t.Run(name, func(t *testing.T) {
+			linkedlisttest.Test(t, func() linkedlist.List[int] {
+				return linkedlist.New[int](opts...)
+			})
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package linkedlist
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// ContextList is implemented by backends whose Find and Get stop walking
+// the list once a context is done.
+type ContextList[T any] interface {
+	FindContext(ctx context.Context, val T) (uint, error)
+	GetContext(ctx context.Context, index uint) (T, error)
+}
+
+// canceled returns ErrCanceled wrapped together with the error of ctx.
+func canceled(ctx context.Context) error {
+	return fmt.Errorf("%w: %w", ErrCanceled, ctx.Err())
+}
+
+// withContext yields the elements of seq until ctx is done.
+func withContext[T any](ctx context.Context, seq iter.Seq2[uint, T]) iter.Seq2[uint, T] {
+	return func(yield func(uint, T) bool) {
+		done := ctx.Done()
+		visited := 0
+		for i, v := range seq {
+			if visited++; visited%checkInterval == 1 {
+				select {
+				case <-done:
+					return
+				default:
+				}
+			}
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
+// findContext returns the index of the first element of seq that match
+// accepts.
+func findContext[T any](ctx context.Context, seq iter.Seq2[uint, T], match func(T) bool) (uint, error) {
+	for i, v := range withContext(ctx, seq) {
+		if match(v) {
+			return i, nil
+		}
+	}
+	if ctx.Err() != nil {
+		return 0, canceled(ctx)
+	}
+	return 0, ErrNotFound
+}
+
+// getContext is GetContext for backends whose Get never walks far: it
+// only looks at ctx before starting.
+func getContext[T any](ctx context.Context, l List[T], index uint) (T, error) {
+	if ctx.Err() != nil {
+		var zero T
+		return zero, canceled(ctx)
+	}
+	return Get(l, index)
+}
+
+// AllContext yields every index and value of l like All, but stops early
+// once ctx is done. Check ctx.Err() after the loop to tell the two apart.
+func AllContext[T any](ctx context.Context, l List[T]) iter.Seq2[uint, T] {
+	return withContext(ctx, l.All())
+}
+
+// FindContext is Find that gives up with ErrCanceled once ctx is done.
+func FindContext[T any](ctx context.Context, l List[T], val T) (uint, error) {
+	if c, ok := l.(ContextList[T]); ok {
+		return c.FindContext(ctx, val)
+	}
+	if ctx.Err() != nil {
+		return 0, canceled(ctx)
+	}
+	return Find(l, val)
+}
+
+// GetContext is Get that gives up with ErrCanceled once ctx is done.
+func GetContext[T any](ctx context.Context, l List[T], index uint) (T, error) {
+	if c, ok := l.(ContextList[T]); ok {
+		return c.GetContext(ctx, index)
+	}
+	return getContext(ctx, l, index)
+}
+
+// HandleListContext is HandleList that gives up with ErrCanceled once ctx
+// is done.
+func HandleListContext[T any](ctx context.Context, l List[T]) ([]T, error) {
+	values := make([]T, 0, l.Len())
+	for _, v := range AllContext(ctx, l) {
+		values = append(values, v)
+	}
+	if ctx.Err() != nil {
+		return nil, canceled(ctx)
+	}
+	return values, nil
+}
+
+func (l *LinkedList[T]) FindContext(ctx context.Context, val T) (uint, error) {
+	// The Bloom filter and the value index answer without a walk.
+	if !l.filter.mayContain(val) || l.index != nil {
+		if ctx.Err() != nil {
+			return 0, canceled(ctx)
+		}
+		return Find[T](l, val)
+	}
+	return findContext(ctx, l.All(), func(v T) bool { return l.equal(v, val) })
+}
+
+// GetContext seeks from the cached segment, walking at most one segment.
+func (l *LinkedList[T]) GetContext(ctx context.Context, index uint) (T, error) {
+	return getContext(ctx, l, index)
+}
+
+func (l *DoublyLinkedList[T]) FindContext(ctx context.Context, val T) (uint, error) {
+	return findContext(ctx, l.All(), func(v T) bool { return l.equal(v, val) })
+}
+
+func (l *DoublyLinkedList[T]) GetContext(ctx context.Context, index uint) (T, error) {
+	return getContext(ctx, l, index)
+}
+
+func (l *SkipList[T]) FindContext(ctx context.Context, val T) (uint, error) {
+	return findContext(ctx, l.All(), func(v T) bool { return l.equal(v, val) })
+}
+
+func (l *SkipList[T]) GetContext(ctx context.Context, index uint) (T, error) {
+	return getContext(ctx, l, index)
+}
+
+func (l *UnrolledList[T]) FindContext(ctx context.Context, val T) (uint, error) {
+	return findContext(ctx, l.All(), func(v T) bool { return l.equal(v, val) })
+}
+
+func (l *UnrolledList[T]) GetContext(ctx context.Context, index uint) (T, error) {
+	return getContext(ctx, l, index)
+}
+
+func (l *Treap[T]) FindContext(ctx context.Context, val T) (uint, error) {
+	return findContext(ctx, l.All(), func(v T) bool { return l.equal(v, val) })
+}
+
+func (l *Treap[T]) GetContext(ctx context.Context, index uint) (T, error) {
+	return getContext(ctx, l, index)
+}
+
+func (l *LockFreeList[T]) FindContext(ctx context.Context, val T) (uint, error) {
+	return findContext(ctx, l.All(), func(v T) bool { return l.equal(v, val) })
+}
+
+// GetContext walks from the head, as Get does.
+func (l *LockFreeList[T]) GetContext(ctx context.Context, index uint) (T, error) {
+	for i, v := range withContext(ctx, l.All()) {
+		if i == index {
+			return v, nil
+		}
+	}
+	var zero T
+	if ctx.Err() != nil {
+		return zero, canceled(ctx)
+	}
+	return zero, ErrIndexOutOfRange
+}
+
+func (l *LockCouplingList[T]) FindContext(ctx context.Context, val T) (uint, error) {
+	return findContext(ctx, l.All(), func(v T) bool { return l.equal(v, val) })
+}
+
+// GetContext walks the segments, as Get does.
+func (l *LockCouplingList[T]) GetContext(ctx context.Context, index uint) (T, error) {
+	var zero T
+	done := ctx.Done()
+	visited := 0
+
+	prev := l.head
+	prev.mutex.RLock()
+	for seg := prev.next; seg != nil; seg = seg.next {
+		if visited++; visited%checkInterval == 1 {
+			select {
+			case <-done:
+				prev.mutex.RUnlock()
+				return zero, canceled(ctx)
+			default:
+			}
+		}
+
+		seg.mutex.RLock()
+		prev.mutex.RUnlock()
+		prev = seg
+
+		if index < uint(len(seg.values)) {
+			val := seg.values[index]
+			seg.mutex.RUnlock()
+			return val, nil
+		}
+		index -= uint(len(seg.values))
+	}
+	prev.mutex.RUnlock()
+	return zero, ErrIndexOutOfRange
+}
+
+func (l *PersistentList[T]) FindContext(ctx context.Context, val T) (uint, error) {
+	return findContext(ctx, l.All(), func(v T) bool { return l.equal(v, val) })
+}
+
+func (l *PersistentList[T]) GetContext(ctx context.Context, index uint) (T, error) {
+	return getContext(ctx, l, index)
+}
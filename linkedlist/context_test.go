@@ -0,0 +1,82 @@
+package linkedlist
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestContextVariants(t *testing.T) {
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = i
+	}
+
+	for _, name := range []string{BackendLinkedList, BackendDoubly, BackendSkipList, BackendUnrolled, BackendTreap, BackendLockFree, BackendLockCoupling, BackendPersistent} {
+		t.Run(name, func(t *testing.T) {
+			l, err := NewBackend(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			Append(l, values...)
+			ctx := context.Background()
+
+			if index, err := FindContext(ctx, l, 700); err != nil || index != 700 {
+				t.Errorf("FindContext: expected 700, got %d, %v", index, err)
+			}
+			if _, err := FindContext(ctx, l, -1); !errors.Is(err, ErrNotFound) {
+				t.Errorf("FindContext of an absent value: expected ErrNotFound, got %v", err)
+			}
+			if v, err := GetContext(ctx, l, 900); err != nil || v != 900 {
+				t.Errorf("GetContext: expected 900, got %d, %v", v, err)
+			}
+			if _, err := GetContext(ctx, l, 1000); !errors.Is(err, ErrIndexOutOfRange) {
+				t.Errorf("GetContext past the end: expected ErrIndexOutOfRange, got %v", err)
+			}
+			if got, err := HandleListContext(ctx, l); err != nil || !slices.Equal(got, values) {
+				t.Errorf("HandleListContext: got %d values, %v", len(got), err)
+			}
+
+			if _, err := FindContext(canceledCtx, l, 700); !errors.Is(err, ErrCanceled) || !errors.Is(err, context.Canceled) {
+				t.Errorf("FindContext after cancel: expected ErrCanceled, got %v", err)
+			}
+			if _, err := GetContext(canceledCtx, l, 900); !errors.Is(err, ErrCanceled) {
+				t.Errorf("GetContext after cancel: expected ErrCanceled, got %v", err)
+			}
+			if _, err := HandleListContext(canceledCtx, l); !errors.Is(err, ErrCanceled) {
+				t.Errorf("HandleListContext after cancel: expected ErrCanceled, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAllContextStopsEarly(t *testing.T) {
+	l := New[int]()
+	l.Append(make([]int, 10*checkInterval)...)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	visited := 0
+	for range AllContext[int](ctx, l) {
+		if visited++; visited == checkInterval/2 {
+			cancel()
+		}
+	}
+	if visited > checkInterval+1 {
+		t.Errorf("visited %d elements after cancel, expected at most %d", visited, checkInterval+1)
+	}
+}
+
+func TestSearchInSegmentedNodesCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, l := range []SegmentSearcher[int]{New[int](), NewDoubly[int]()} {
+		Append(l.(List[int]), 1, 2, 3)
+		if _, ok := l.SearchInSegmentedNodes(ctx, 1); ok {
+			t.Errorf("%T: SearchInSegmentedNodes succeeded after cancel", l)
+		}
+	}
+}
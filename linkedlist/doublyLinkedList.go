@@ -0,0 +1,412 @@
+package linkedlist
+
+import "context"
+
+type DoublyNode[T any] struct {
+	Value T
+	Next  *DoublyNode[T]
+	Prev  *DoublyNode[T]
+}
+
+// DoublyLinkedList is a doubly linked list of T with a tail pointer. It has
+// the same methods as LinkedList, plus O(1) push and pop at both ends, and
+// seeks from whichever end or cached segment is closest to an index.
+type DoublyLinkedList[T any] struct {
+	head   *DoublyNode[T]
+	tail   *DoublyNode[T]
+	length uint
+	equal  func(a, b T) bool
+
+	// Segment k starts at position lead+k*part, with the lead nodes before
+	// nodes[0] reached from head. Inserting or removing before nodes[0]
+	// only changes lead, which keeps both ends O(1) without touching the
+	// cache, until lead reaches part and head starts a new first segment.
+	nodes     []*DoublyNode[T]
+	lead      uint
+	part      uint
+	fixedPart bool
+	workers   int
+
+	// spare is the array behind nodes while it has room before nodes[0],
+	// which starts at spare[first], so that prepending a segment is
+	// amortized O(1).
+	spare []*DoublyNode[T]
+	first int
+}
+
+// NewDoubly returns an empty doubly linked list that compares values with ==.
+func NewDoubly[T comparable](opts ...Option) *DoublyLinkedList[T] {
+	return NewDoublyFunc(func(a, b T) bool { return a == b }, opts...)
+}
+
+// NewDoublyFunc returns an empty doubly linked list that compares values
+// with equal.
+func NewDoublyFunc[T any](equal func(a, b T) bool, opts ...Option) *DoublyLinkedList[T] {
+	o := newOptions(opts)
+	return &DoublyLinkedList[T]{equal: equal, part: o.part, fixedPart: o.fixedPart, workers: o.workers}
+}
+
+func NewDoublyLinkedList() *DoublyLinkedList[int] {
+	return NewDoubly[int]()
+}
+
+func (l *DoublyLinkedList[T]) Len() uint {
+	return l.length
+}
+
+func (l *DoublyLinkedList[T]) Find(val T) (index uint, found bool) {
+	current := l.head
+	index = 0
+	for current != nil {
+		if l.equal(current.Value, val) {
+			return index, true
+		}
+		current = current.Next
+		index++
+	}
+	return 0, false
+}
+
+func (l *DoublyLinkedList[T]) Remove(index uint) bool {
+	if index >= l.length {
+		return false
+	}
+
+	current := l.seek(index)
+	l.updateCacheForRemove(index)
+	l.unlink(current)
+	l.resizeSegments()
+
+	return true
+}
+
+func (l *DoublyLinkedList[T]) Get(index uint) (T, bool) {
+	if index >= l.length {
+		var zero T
+		return zero, false
+	}
+
+	return l.seek(index).Value, true
+}
+
+func (l *DoublyLinkedList[T]) Insert(index uint, val T) bool {
+	if index > l.length {
+		return false
+	}
+
+	newNode := &DoublyNode[T]{Value: val}
+	if index == l.length {
+		l.linkBefore(newNode, nil)
+	} else {
+		l.linkBefore(newNode, l.seek(index))
+	}
+
+	l.updateCacheForInsert(index)
+	l.resizeSegments()
+
+	return true
+}
+
+// PushFront inserts val at the head in O(1) amortized time.
+func (l *DoublyLinkedList[T]) PushFront(val T) {
+	l.Insert(0, val)
+}
+
+func (l *DoublyLinkedList[T]) PushBack(val T) {
+	l.Insert(l.length, val)
+}
+
+// PopFront removes and returns the head in O(1) amortized time.
+func (l *DoublyLinkedList[T]) PopFront() (T, bool) {
+	if l.length == 0 {
+		var zero T
+		return zero, false
+	}
+
+	val := l.head.Value
+	l.Remove(0)
+	return val, true
+}
+
+func (l *DoublyLinkedList[T]) PopBack() (T, bool) {
+	if l.length == 0 {
+		var zero T
+		return zero, false
+	}
+
+	val := l.tail.Value
+	l.Remove(l.length - 1)
+	return val, true
+}
+
+func (l *DoublyLinkedList[T]) Front() (T, bool) {
+	if l.head == nil {
+		var zero T
+		return zero, false
+	}
+	return l.head.Value, true
+}
+
+func (l *DoublyLinkedList[T]) Back() (T, bool) {
+	if l.tail == nil {
+		var zero T
+		return zero, false
+	}
+	return l.tail.Value, true
+}
+
+// linkBefore links node in front of next, or at the tail when next is nil.
+func (l *DoublyLinkedList[T]) linkBefore(node, next *DoublyNode[T]) {
+	node.Next = next
+	if next == nil {
+		node.Prev = l.tail
+		l.tail = node
+	} else {
+		node.Prev = next.Prev
+		next.Prev = node
+	}
+
+	if node.Prev == nil {
+		l.head = node
+	} else {
+		node.Prev.Next = node
+	}
+	l.length++
+}
+
+func (l *DoublyLinkedList[T]) unlink(node *DoublyNode[T]) {
+	if node.Prev == nil {
+		l.head = node.Next
+	} else {
+		node.Prev.Next = node.Next
+	}
+
+	if node.Next == nil {
+		l.tail = node.Prev
+	} else {
+		node.Next.Prev = node.Prev
+	}
+	l.length--
+}
+
+// seek returns the node at index, which must be below l.length. It walks
+// from the closest of the tail and the two cached segments around index.
+func (l *DoublyLinkedList[T]) seek(index uint) *DoublyNode[T] {
+	current, steps, forward := l.head, index, true
+
+	if back := l.length - 1 - index; back < steps {
+		current, steps, forward = l.tail, back, false
+	}
+
+	if index < l.lead {
+		if back := l.lead - index; len(l.nodes) > 0 && back < steps {
+			current, steps, forward = l.nodes[0], back, false
+		}
+	} else if segment := (index - l.lead) / l.part; segment < uint(len(l.nodes)) {
+		if offset := index - l.start(int(segment)); offset < steps {
+			current, steps, forward = l.nodes[segment], offset, true
+		}
+		if segment+1 < uint(len(l.nodes)) {
+			if back := l.start(int(segment)+1) - index; back < steps {
+				current, steps, forward = l.nodes[segment+1], back, false
+			}
+		}
+	}
+
+	for ; steps > 0; steps-- {
+		if forward {
+			current = current.Next
+		} else {
+			current = current.Prev
+		}
+	}
+	return current
+}
+
+// start returns the position of the first node of segment i.
+func (l *DoublyLinkedList[T]) start(i int) uint {
+	return l.lead + uint(i)*l.part
+}
+
+// segments returns the number of segments a list of length nodes has.
+func (l *DoublyLinkedList[T]) segments(length uint) int {
+	if length <= l.lead {
+		return 0
+	}
+	return int((length - l.lead + l.part - 1) / l.part)
+}
+
+// updateCacheForRemove must be called before the node at index is unlinked.
+// Removing a lead node shifts every segment, so it only shortens lead,
+// and removing the head when it starts segment 0 drops that segment.
+// Otherwise every segment starting at or after index now starts one node
+// later.
+func (l *DoublyLinkedList[T]) updateCacheForRemove(index uint) {
+	switch {
+	case index < l.lead:
+		l.lead--
+		return
+	case index == l.lead && l.lead > 0:
+		l.nodes[0] = l.nodes[0].Prev
+		l.lead--
+		return
+	case index == 0:
+		l.dropFirstSegment()
+		return
+	}
+
+	for i := int((index - l.lead + l.part - 1) / l.part); i < len(l.nodes); i++ {
+		if l.nodes[i].Next == nil {
+			clear(l.nodes[i:])
+			l.nodes = l.nodes[:i]
+			break
+		}
+		l.nodes[i] = l.nodes[i].Next
+	}
+}
+
+// updateCacheForInsert must be called after the new node is linked at
+// index. Inserting at or before the first segment only lengthens lead.
+// Otherwise every segment starting at or after index now starts one node
+// earlier, which Prev gives without walking the segment.
+func (l *DoublyLinkedList[T]) updateCacheForInsert(index uint) {
+	if index <= l.lead {
+		l.lead++
+		if l.lead == l.part {
+			l.prependSegment(l.head)
+		}
+		return
+	}
+
+	for i := int((index - l.lead + l.part - 1) / l.part); i < len(l.nodes); i++ {
+		l.nodes[i] = l.nodes[i].Prev
+	}
+
+	if (l.length-1-l.lead)%l.part == 0 {
+		l.nodes = append(l.nodes, l.tail)
+	}
+}
+
+// prependSegment makes head, part nodes before nodes[0], the start of a
+// new segment 0. When nodes has no room before it, it moves to a new spare
+// with as much room before the segments as they take.
+func (l *DoublyLinkedList[T]) prependSegment(head *DoublyNode[T]) {
+	if l.first <= 0 || l.first >= len(l.spare) || len(l.nodes) == 0 || &l.spare[l.first] != &l.nodes[0] {
+		room := len(l.nodes) + 1
+		l.spare = make([]*DoublyNode[T], room+2*len(l.nodes))
+		l.first = room
+		l.nodes = append(l.spare[room:room], l.nodes...)
+	}
+	l.first--
+	l.spare[l.first] = head
+	l.nodes = l.spare[l.first : l.first+len(l.nodes)+1]
+	l.lead = 0
+}
+
+// dropFirstSegment drops segment 0 when its first node, the head, is about
+// to be removed. The next segment then starts part-1 nodes after the new
+// head.
+func (l *DoublyLinkedList[T]) dropFirstSegment() {
+	l.nodes[0] = nil
+	l.nodes = l.nodes[1:]
+	l.first++
+	l.lead = l.part - 1
+}
+
+// refreshCacheFrom rewrites every segment pointer at or after index by
+// walking from node, the node now at index, and drops the segments past
+// the new length.
+func (l *DoublyLinkedList[T]) refreshCacheFrom(index uint, node *DoublyNode[T]) {
+	counter := index
+	for current := node; current != nil; current = current.Next {
+		if counter >= l.lead && (counter-l.lead)%l.part == 0 {
+			partIndex := int((counter - l.lead) / l.part)
+			if partIndex >= len(l.nodes) {
+				l.nodes = append(l.nodes, current)
+			} else {
+				l.nodes[partIndex] = current
+			}
+		}
+		counter++
+	}
+
+	if segments := l.segments(l.length); segments < len(l.nodes) {
+		clear(l.nodes[segments:])
+		l.nodes = l.nodes[:segments]
+	}
+}
+
+// SegmentSize returns the number of nodes between two cached segment pointers.
+func (l *DoublyLinkedList[T]) SegmentSize() uint {
+	return l.part
+}
+
+// SegmentCount returns the number of cached segment pointers.
+func (l *DoublyLinkedList[T]) SegmentCount() int {
+	return len(l.nodes)
+}
+
+func (l *DoublyLinkedList[T]) resizeSegments() bool {
+	if l.fixedPart {
+		return false
+	}
+
+	part := segmentSizeFor(l.length)
+	if part >= 2*l.part || 2*part <= l.part {
+		l.part = part
+		l.rebuildCache()
+		return true
+	}
+	return false
+}
+
+func (l *DoublyLinkedList[T]) rebuildCache() {
+	l.lead, l.spare, l.first = 0, nil, 0
+	l.nodes = make([]*DoublyNode[T], 0, (l.length+l.part-1)/l.part)
+	var counter uint
+	for current := l.head; current != nil; current = current.Next {
+		if counter%l.part == 0 {
+			l.nodes = append(l.nodes, current)
+		}
+		counter++
+	}
+}
+
+func (l *DoublyLinkedList[T]) HandleList() []T {
+	current := l.head
+	var values []T
+	for current != nil {
+		values = append(values, current.Value)
+		current = current.Next
+	}
+	return values
+}
+
+// HandleListBackward returns the values from tail to head.
+func (l *DoublyLinkedList[T]) HandleListBackward() []T {
+	current := l.tail
+	var values []T
+	for current != nil {
+		values = append(values, current.Value)
+		current = current.Prev
+	}
+	return values
+}
+
+// SearchConcurrently returns the index of the first element equal to find,
+// scanning the segments on a bounded pool of workers. It reports false if
+// ctx is done before the search completes.
+func (l *DoublyLinkedList[T]) SearchConcurrently(ctx context.Context, find T) (int, bool) {
+	index, found := l.scan().first(ctx, func(v T) bool { return l.equal(v, find) })
+	return int(index), found
+}
+
+// SearchInSegmentedNodes returns the element at index. It reports false if
+// ctx is already done.
+func (l *DoublyLinkedList[T]) SearchInSegmentedNodes(ctx context.Context, index int) (T, bool) {
+	if index < 0 || ctx.Err() != nil {
+		var zero T
+		return zero, false
+	}
+	return l.Get(uint(index))
+}
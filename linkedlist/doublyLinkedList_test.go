@@ -0,0 +1,161 @@
+package linkedlist
+
+import (
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+func TestDoublyLinkedListPropertiesQuick(t *testing.T) {
+	err := quick.Check(func(ops []uint16, part uint8) bool {
+		l := NewDoubly[int](WithSegmentSize(uint(part%16) + 1))
+		var model []int
+
+		for k, op := range ops {
+			switch {
+			case op%6 == 0 && len(model) > 0:
+				index := uint(op) % uint(len(model))
+				if !l.Remove(index) {
+					return false
+				}
+				model = slices.Delete(model, int(index), int(index)+1)
+			case op%6 == 1:
+				l.PushFront(k)
+				model = slices.Insert(model, 0, k)
+			case op%6 == 2:
+				v, ok := l.PopBack()
+				if ok != (len(model) > 0) {
+					return false
+				}
+				if ok {
+					if v != model[len(model)-1] {
+						return false
+					}
+					model = model[:len(model)-1]
+				}
+			case op%6 == 3:
+				v, ok := l.PopFront()
+				if ok != (len(model) > 0) {
+					return false
+				}
+				if ok {
+					if v != model[0] {
+						return false
+					}
+					model = model[1:]
+				}
+			default:
+				index := uint(op) % uint(len(model)+1)
+				if !l.Insert(index, k) {
+					return false
+				}
+				model = slices.Insert(model, int(index), k)
+			}
+
+			if l.length != uint(len(model)) {
+				return false
+			}
+			if l.Validate() != nil {
+				return false
+			}
+			for i, node := range l.nodes {
+				if node.Value != model[l.start(i)] {
+					return false
+				}
+			}
+		}
+
+		for k, v := range model {
+			if out, ok := l.Get(uint(k)); !ok || out != v {
+				return false
+			}
+		}
+		if !slices.Equal(l.HandleList(), model) {
+			return false
+		}
+		backward := slices.Clone(model)
+		slices.Reverse(backward)
+		return slices.Equal(l.HandleListBackward(), backward)
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDoublyLinkedListEnds(t *testing.T) {
+	l := NewDoublyLinkedList()
+
+	if _, ok := l.PopFront(); ok {
+		t.Error("PopFront did not fail on an empty list")
+	}
+	if _, ok := l.Back(); ok {
+		t.Error("Back did not fail on an empty list")
+	}
+
+	l.PushBack(20)
+	l.PushFront(10)
+	l.PushBack(30)
+
+	if front, _ := l.Front(); front != 10 {
+		t.Errorf("Expected front 10, got %d", front)
+	}
+	if back, _ := l.Back(); back != 30 {
+		t.Errorf("Expected back 30, got %d", back)
+	}
+	if v, ok := l.PopFront(); !ok || v != 10 {
+		t.Errorf("PopFront: expected 10, got %d, ok %t", v, ok)
+	}
+	if v, ok := l.PopBack(); !ok || v != 30 {
+		t.Errorf("PopBack: expected 30, got %d, ok %t", v, ok)
+	}
+	if l.head != l.tail || l.head.Value != 20 || l.length != 1 {
+		t.Errorf("Expected single node 20, got head %v, tail %v and length %d", l.head, l.tail, l.length)
+	}
+	if l.Insert(2, 40) {
+		t.Error("Insert did not fail when trying to insert out of bounds")
+	}
+}
+
+// TestDoublyLinkedListFrontKeepsCache checks that pushing and popping at
+// the head leaves the cached segments after the first one alone.
+func TestDoublyLinkedListFrontKeepsCache(t *testing.T) {
+	l := NewDoubly[int](WithSegmentSize(4))
+	l.Append(make([]int, 100)...)
+	last := l.nodes[len(l.nodes)-1]
+
+	for i := range 1000 {
+		l.PushFront(i)
+		if l.nodes[len(l.nodes)-1] != last {
+			t.Fatalf("PushFront %d moved the last cached segment", i)
+		}
+		if err := l.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := range 1000 {
+		if v, ok := l.PopFront(); !ok || v != 999-i {
+			t.Fatalf("PopFront: expected %d, got %d, ok %t", 999-i, v, ok)
+		}
+		if l.nodes[len(l.nodes)-1] != last {
+			t.Fatalf("PopFront %d moved the last cached segment", i)
+		}
+		if err := l.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestDoublyLinkedListFrontConstantTime fails if a push and pop at the
+// head cost more at 100k elements than at 1k.
+func TestDoublyLinkedListFrontConstantTime(t *testing.T) {
+	if testing.Short() {
+		t.Skip("times benchmarks")
+	}
+
+	small := testing.Benchmark(benchmarkDoublyFront(1_000))
+	large := testing.Benchmark(benchmarkDoublyFront(benchmarkSize))
+	if large.NsPerOp() > 3*max(small.NsPerOp(), 10) {
+		t.Errorf("push and pop at the head took %d ns at %d elements and %d ns at %d", small.NsPerOp(), 1_000, large.NsPerOp(), benchmarkSize)
+	}
+}
@@ -0,0 +1,113 @@
+package linkedlist
+
+import (
+	"context"
+	"errors"
+)
+
+// Errors returned by Insert, Remove, Get, Find and Search. Test for them
+// with errors.Is.
+var (
+	ErrIndexOutOfRange  = errors.New("linkedlist: index out of range")
+	ErrNotFound         = errors.New("linkedlist: value not found")
+	ErrCapacityExceeded = errors.New("linkedlist: capacity exceeded")
+	// ErrNotSorted is returned instead of a sorted insert into a list that
+	// is not in sorted mode.
+	ErrNotSorted = errors.New("linkedlist: list not sorted")
+	// ErrCanceled is wrapped together with the error of the context that
+	// ended the search, so errors.Is also matches context.DeadlineExceeded
+	// or context.Canceled.
+	ErrCanceled = errors.New("linkedlist: search canceled")
+)
+
+// Bounded is implemented by backends that can hold a limited number of
+// elements. A Capacity of 0 means no limit.
+type Bounded interface {
+	Capacity() uint
+}
+
+// WithCapacity limits the list to n elements: inserts that would exceed it
+// fail. It applies to lists created by New and NewFunc.
+func WithCapacity(n uint) Option {
+	return func(o *options) {
+		o.capacity = n
+	}
+}
+
+// Capacity returns the maximum number of elements, or 0 for no limit.
+func (l *LinkedList[T]) Capacity() uint {
+	return l.capacity
+}
+
+// fits reports whether n more elements fit within the capacity.
+func (l *LinkedList[T]) fits(n uint) bool {
+	return l.capacity == 0 || l.length+n <= l.capacity
+}
+
+// CheckCapacity returns ErrCapacityExceeded if n more elements do not fit
+// in l.
+func CheckCapacity[T any](l List[T], n uint) error {
+	if b, ok := l.(Bounded); ok && b.Capacity() > 0 && l.Len()+n > b.Capacity() {
+		return ErrCapacityExceeded
+	}
+	return nil
+}
+
+// Insert inserts val into l at index. It returns ErrIndexOutOfRange if
+// index is past the end and ErrCapacityExceeded if l is full.
+func Insert[T any](l List[T], index uint, val T) error {
+	if l.Insert(index, val) {
+		return nil
+	}
+	if index <= l.Len() {
+		if err := CheckCapacity(l, 1); err != nil {
+			return err
+		}
+	}
+	return ErrIndexOutOfRange
+}
+
+// Remove removes the element of l at index, or returns ErrIndexOutOfRange.
+func Remove[T any](l List[T], index uint) error {
+	if !l.Remove(index) {
+		return ErrIndexOutOfRange
+	}
+	return nil
+}
+
+// Get returns the element of l at index, or ErrIndexOutOfRange.
+func Get[T any](l List[T], index uint) (T, error) {
+	val, ok := l.Get(index)
+	if !ok {
+		return val, ErrIndexOutOfRange
+	}
+	return val, nil
+}
+
+// Find returns the index of the first element of l equal to val, or
+// ErrNotFound.
+func Find[T any](l List[T], val T) (uint, error) {
+	index, found := l.Find(val)
+	if !found {
+		return 0, ErrNotFound
+	}
+	return index, nil
+}
+
+// Search is Find through SearchConcurrently when l is a SegmentSearcher.
+// It returns ErrCanceled if ctx ends before the search does.
+func Search[T any](ctx context.Context, l List[T], val T) (uint, error) {
+	searcher, ok := l.(SegmentSearcher[T])
+	if !ok {
+		return Find(l, val)
+	}
+
+	index, found := searcher.SearchConcurrently(ctx, val)
+	if ctx.Err() != nil && !found {
+		return 0, canceled(ctx)
+	}
+	if !found {
+		return 0, ErrNotFound
+	}
+	return uint(index), nil
+}
@@ -0,0 +1,104 @@
+package linkedlist
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	for _, name := range []string{BackendLinkedList, BackendDoubly, BackendSkipList, BackendUnrolled, BackendTreap, BackendLockFree, BackendLockCoupling, BackendPersistent} {
+		t.Run(name, func(t *testing.T) {
+			l, err := NewBackend(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := Insert(l, 1, 1); !errors.Is(err, ErrIndexOutOfRange) {
+				t.Errorf("Insert past the end: expected ErrIndexOutOfRange, got %v", err)
+			}
+			if err := Insert(l, 0, 1); err != nil {
+				t.Errorf("Insert: %v", err)
+			}
+			if v, err := Get(l, 0); err != nil || v != 1 {
+				t.Errorf("Get: expected 1, got %d, %v", v, err)
+			}
+			if _, err := Get(l, 1); !errors.Is(err, ErrIndexOutOfRange) {
+				t.Errorf("Get past the end: expected ErrIndexOutOfRange, got %v", err)
+			}
+			if index, err := Find(l, 1); err != nil || index != 0 {
+				t.Errorf("Find: expected 0, got %d, %v", index, err)
+			}
+			if _, err := Find(l, 2); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Find of an absent value: expected ErrNotFound, got %v", err)
+			}
+			if _, err := Search(context.Background(), l, 2); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Search of an absent value: expected ErrNotFound, got %v", err)
+			}
+			if err := Remove(l, 1); !errors.Is(err, ErrIndexOutOfRange) {
+				t.Errorf("Remove past the end: expected ErrIndexOutOfRange, got %v", err)
+			}
+			if err := Remove(l, 0); err != nil {
+				t.Errorf("Remove: %v", err)
+			}
+		})
+	}
+}
+
+func TestSearchCanceled(t *testing.T) {
+	l := New[int]()
+	l.Append(1, 2, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Search(ctx, l, 4)
+	if !errors.Is(err, ErrCanceled) || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected ErrCanceled wrapping context.Canceled, got %v", err)
+	}
+}
+
+func TestCapacity(t *testing.T) {
+	l := New[int](WithCapacity(3))
+	if err := Insert(l, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	if l.InsertAll(0, []int{2, 3, 4}) {
+		t.Error("InsertAll exceeded the capacity")
+	}
+	l.Append(2, 3)
+	if err := Insert(l, 3, 4); !errors.Is(err, ErrCapacityExceeded) {
+		t.Errorf("expected ErrCapacityExceeded, got %v", err)
+	}
+	if err := Insert(l, 4, 4); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("expected ErrIndexOutOfRange past the end of a full list, got %v", err)
+	}
+	if err := CheckCapacity[int](l, 1); !errors.Is(err, ErrCapacityExceeded) {
+		t.Errorf("expected ErrCapacityExceeded, got %v", err)
+	}
+
+	l.Sort(func(a, b int) bool { return a < b })
+	if _, ok := l.InsertSorted(0); ok {
+		t.Error("InsertSorted exceeded the capacity")
+	}
+	other := New[int]()
+	other.Append(5)
+	if l.Splice(0, other) || other.Len() != 1 {
+		t.Error("Splice exceeded the capacity")
+	}
+	if l.Concat(other) || other.Len() != 1 {
+		t.Error("Concat exceeded the capacity")
+	}
+	if l.Len() != 3 {
+		t.Errorf("expected 3 elements, got %d", l.Len())
+	}
+
+	if err := Remove(l, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := Insert(l, 0, 1); err != nil {
+		t.Errorf("Insert below the capacity: %v", err)
+	}
+	if err := CheckCapacity(NewLockFree[int](), 1<<20); err != nil {
+		t.Errorf("unbounded backend reported %v", err)
+	}
+}
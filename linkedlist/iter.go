@@ -0,0 +1,106 @@
+package linkedlist
+
+import "iter"
+
+// The iterators below read nodes lazily and copy nothing but the current
+// value. They are not safe against concurrent modification: inserting or
+// removing elements while a loop is running, from the loop body or from
+// another goroutine, may skip or repeat elements and report stale indexes.
+// Callers that share a list must hold its lock for the whole loop.
+
+// All yields every index and value from head to tail.
+func (l *LinkedList[T]) All() iter.Seq2[uint, T] {
+	return l.From(0)
+}
+
+// Values yields every value from head to tail.
+func (l *LinkedList[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for current := l.head; current != nil; current = current.Next {
+			if !yield(current.Value) {
+				return
+			}
+		}
+	}
+}
+
+// From yields the indexes and values from index to tail.
+func (l *LinkedList[T]) From(index uint) iter.Seq2[uint, T] {
+	return func(yield func(uint, T) bool) {
+		if index >= l.length {
+			return
+		}
+		for current := l.seek(index); current != nil; current = current.Next {
+			if !yield(index, current.Value) {
+				return
+			}
+			index++
+		}
+	}
+}
+
+// Backward yields every index and value from tail to head. Nodes only link
+// forward, so it buffers one cached segment at a time.
+func (l *LinkedList[T]) Backward() iter.Seq2[uint, T] {
+	return func(yield func(uint, T) bool) {
+		segment := make([]*Node[T], 0, 2*l.part)
+		for i := len(l.nodes) - 1; i >= 0; i-- {
+			segment = segment[:0]
+			current := l.nodes[i]
+			for range l.segmentEnd(i) - l.starts[i] {
+				segment = append(segment, current)
+				current = current.Next
+			}
+
+			for j := len(segment) - 1; j >= 0; j-- {
+				if !yield(l.starts[i]+uint(j), segment[j].Value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// All yields every index and value from head to tail.
+func (l *DoublyLinkedList[T]) All() iter.Seq2[uint, T] {
+	return l.From(0)
+}
+
+// Values yields every value from head to tail.
+func (l *DoublyLinkedList[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for current := l.head; current != nil; current = current.Next {
+			if !yield(current.Value) {
+				return
+			}
+		}
+	}
+}
+
+// From yields the indexes and values from index to tail.
+func (l *DoublyLinkedList[T]) From(index uint) iter.Seq2[uint, T] {
+	return func(yield func(uint, T) bool) {
+		if index >= l.length {
+			return
+		}
+		for current := l.seek(index); current != nil; current = current.Next {
+			if !yield(index, current.Value) {
+				return
+			}
+			index++
+		}
+	}
+}
+
+// Backward yields every index and value from tail to head.
+func (l *DoublyLinkedList[T]) Backward() iter.Seq2[uint, T] {
+	return func(yield func(uint, T) bool) {
+		index := l.length
+		for current := l.tail; current != nil; current = current.Prev {
+			index--
+			if !yield(index, current.Value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package linkedlist
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+type iterList interface {
+	List[int]
+	Values() iter.Seq[int]
+	From(index uint) iter.Seq2[uint, int]
+	Backward() iter.Seq2[uint, int]
+}
+
+func TestIterators(t *testing.T) {
+	const n = 57
+	lists := map[string]iterList{
+		"linkedlist": New[int](WithSegmentSize(10)),
+		"doubly":     NewDoubly[int](WithSegmentSize(10)),
+	}
+
+	for name, l := range lists {
+		for i := 0; i < n; i++ {
+			l.Insert(uint(i), i*2)
+		}
+
+		if values := slices.Collect(l.Values()); !slices.Equal(values, l.HandleList()) {
+			t.Errorf("%s: Values: expected %v, got %v", name, l.HandleList(), values)
+		}
+
+		var forward []uint
+		for i, v := range l.All() {
+			if v != int(i)*2 {
+				t.Errorf("%s: All: expected %d at index %d, got %d", name, int(i)*2, i, v)
+			}
+			forward = append(forward, i)
+		}
+		if len(forward) != n {
+			t.Errorf("%s: All: expected %d elements, got %d", name, n, len(forward))
+		}
+
+		var backward []uint
+		for i, v := range l.Backward() {
+			if v != int(i)*2 {
+				t.Errorf("%s: Backward: expected %d at index %d, got %d", name, int(i)*2, i, v)
+			}
+			backward = append(backward, i)
+		}
+		slices.Reverse(backward)
+		if !slices.Equal(forward, backward) {
+			t.Errorf("%s: Backward: expected indexes %v, got %v", name, forward, backward)
+		}
+
+		var from []uint
+		for i := range l.From(n - 3) {
+			from = append(from, i)
+		}
+		if !slices.Equal(from, []uint{n - 3, n - 2, n - 1}) {
+			t.Errorf("%s: From(%d): got indexes %v", name, n-3, from)
+		}
+		for range l.From(n) {
+			t.Errorf("%s: From(%d): expected no elements", name, n)
+		}
+
+		for i := range l.Backward() {
+			if i != n-1 {
+				t.Errorf("%s: Backward: expected to stop after index %d, got %d", name, n-1, i)
+			}
+			break
+		}
+	}
+}
@@ -2,33 +2,154 @@ package linkedlist
 
 import (
 	"context"
-	"sync"
+	"math"
+	"slices"
+	"sort"
 )
 
-type Node struct {
-	Value int
-	Next  *Node
+type Node[T any] struct {
+	Value T
+	Next  *Node[T]
+	// label orders the node for the value index.
+	label uint64
 }
 
-type LinkedList struct {
-	head   *Node
+// LinkedList is a singly linked list of T. Values are compared with the
+// equal function given at construction, so T does not need to be comparable.
+type LinkedList[T any] struct {
+	head   *Node[T]
 	length uint
+	equal  func(a, b T) bool
+
+	// nodes caches a pointer to the first node of every segment and starts
+	// its position. A segment holds at most 2*part nodes, so an insert
+	// shifts the later starts instead of their pointers. Unless fixedPart is
+	// set, part follows the square root of length.
+	nodes     []*Node[T]
+	starts    []uint
+	part      uint
+	fixedPart bool
+	// workers bounds the goroutines of the concurrent scans, 0 meaning
+	// GOMAXPROCS.
+	workers int
+	// capacity is the maximum length, 0 meaning no limit.
+	capacity uint
+
+	// less is the order of the last Sort, and sorted tells whether the
+	// list still follows it.
+	less   func(a, b T) bool
+	sorted bool
+
+	// index is nil unless the list was created with WithValueIndex, and
+	// filter unless it was created with WithBloomFilter.
+	index  *valueIndex[T]
+	filter *bloomFilter[T]
 }
 
-var (
-	Nodes []*Node
-	part  uint = 10
-)
+const defaultPart uint = 10
+
+// Option configures a list created by New, NewFunc or NewLockCoupling.
+type Option func(*options)
+
+type options struct {
+	part       uint
+	fixedPart  bool
+	valueIndex bool
+	workers    int
+	capacity   uint
+
+	bloomCapacity uint
+	bloomRate     float64
+}
+
+// WithSegmentSize fixes the number of nodes between two cached segment
+// pointers. By default the segment size adapts to the list length.
+func WithSegmentSize(n uint) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.part = n
+			o.fixedPart = true
+		}
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{part: defaultPart}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// names returns the names of the options set in o.
+func (o options) names() []string {
+	var names []string
+	if o.fixedPart {
+		names = append(names, "WithSegmentSize")
+	}
+	if o.workers > 0 {
+		names = append(names, "WithScanWorkers")
+	}
+	if o.capacity > 0 {
+		names = append(names, "WithCapacity")
+	}
+	if o.valueIndex {
+		names = append(names, "WithValueIndex")
+	}
+	if o.bloomCapacity > 0 {
+		names = append(names, "WithBloomFilter")
+	}
+	return names
+}
+
+// New returns an empty list that compares values with ==.
+func New[T comparable](opts ...Option) *LinkedList[T] {
+	l := NewFunc(func(a, b T) bool { return a == b }, opts...)
+	o := newOptions(opts)
+	if o.valueIndex {
+		l.index = newValueIndex[T]()
+	}
+	if o.bloomCapacity > 0 {
+		l.filter = newBloomFilter[T](o.bloomCapacity, o.bloomRate)
+	}
+	return l
+}
+
+// NewFunc returns an empty list that compares values with equal.
+func NewFunc[T any](equal func(a, b T) bool, opts ...Option) *LinkedList[T] {
+	o := newOptions(opts)
+	return &LinkedList[T]{
+		equal:     equal,
+		part:      o.part,
+		fixedPart: o.fixedPart,
+		workers:   o.workers,
+		capacity:  o.capacity,
+	}
+}
+
+func NewLinkedList() *LinkedList[int] {
+	return New[int]()
+}
 
-func NewLinkedList() *LinkedList {
-	return &LinkedList{}
+func (l *LinkedList[T]) Len() uint {
+	return l.length
 }
 
-func (l *LinkedList) Find(val int) (index uint, found bool) {
+func (l *LinkedList[T]) Find(val T) (index uint, found bool) {
+	if !l.filter.mayContain(val) {
+		return 0, false
+	}
+	if l.index != nil {
+		if nodes := l.index.positions[val]; len(nodes) > 0 {
+			return l.indexOf(nodes[0]), true
+		}
+		return 0, false
+	}
+
 	current := l.head
 	index = 0
 	for current != nil {
-		if current.Value == val {
+		if l.equal(current.Value, val) {
 			return index, true
 		}
 		current = current.Next
@@ -37,128 +158,249 @@ func (l *LinkedList) Find(val int) (index uint, found bool) {
 	return 0, false
 }
 
-func (l *LinkedList) Remove(index uint) bool {
+func (l *LinkedList[T]) Remove(index uint) bool {
 	if index >= l.length {
 		return false
 	}
-	l.updateCacheForRemove(index)
+
 	if index == 0 {
+		l.indexRemoved(l.head)
+		l.filter.remove(l.head.Value)
+		l.updateCacheForRemove(index)
 		l.head = l.head.Next
 		l.length--
+		l.resizeSegments()
 		return true
 	}
 
-	current := l.head
-	for i := uint(0); i < index-1; i++ {
-		if current.Next == nil {
-			return false
-		}
-		current = current.Next
-	}
-
-	if current.Next == nil {
-		return false
-	}
-
+	current := l.seek(index - 1)
+	l.indexRemoved(current.Next)
+	l.filter.remove(current.Next.Value)
+	l.updateCacheForRemove(index)
 	current.Next = current.Next.Next
 	l.length--
+	l.resizeSegments()
 
 	return true
 }
 
-func (l *LinkedList) Get(index uint) (int, bool) {
-	current := l.head
-	for i := uint(0); i < index; i++ {
-		if current == nil {
-			return 0, false
-		}
-		current = current.Next
-	}
-
-	if current == nil {
-		return 0, false
+func (l *LinkedList[T]) Get(index uint) (T, bool) {
+	if index >= l.length {
+		var zero T
+		return zero, false
 	}
 
-	return current.Value, true
+	return l.seek(index).Value, true
 }
 
-func (l *LinkedList) Insert(index uint, val int) bool {
-	if index > l.length {
+func (l *LinkedList[T]) Insert(index uint, val T) bool {
+	if index > l.length || !l.fits(1) {
 		return false
 	}
 
-	newNode := &Node{Value: val}
+	newNode := &Node[T]{Value: val}
 
 	if index == 0 {
 		newNode.Next = l.head
 		l.head = newNode
 		l.length++
+		l.checkOrder(newNode, newNode)
+		l.indexInserted(nil, newNode, 1)
+		l.filter.add(val)
 		l.updateCacheForInsert(index, newNode)
+		l.resizeSegments()
 		return true
 	}
 
-	current := l.head
-	for i := uint(0); i < index-1; i++ {
-		if current == nil {
-			return false
-		}
-		current = current.Next
-	}
-
+	current := l.seek(index - 1)
 	newNode.Next = current.Next
 	current.Next = newNode
 	l.length++
+	l.checkOrder(current, newNode)
+	l.indexInserted(current, newNode, 1)
+	l.filter.add(val)
 
 	l.updateCacheForInsert(index, newNode)
+	l.resizeSegments()
 
 	return true
 }
 
-func (l *LinkedList) updateCacheForRemove(index uint) {
+// SegmentSize returns the number of nodes between two cached segment pointers.
+func (l *LinkedList[T]) SegmentSize() uint {
+	return l.part
+}
 
-	indexNodes := int(index/part) + 1
+// SegmentCount returns the number of cached segment pointers.
+func (l *LinkedList[T]) SegmentCount() int {
+	return len(l.nodes)
+}
 
-	if index == 0 {
-		indexNodes = 0
+// segmentSizeFor returns the segment size that balances the number of
+// segments against the nodes walked inside one, about sqrt(n).
+func segmentSizeFor(n uint) uint {
+	part := uint(math.Sqrt(float64(n)))
+	if part < defaultPart {
+		return defaultPart
+	}
+	return part
+}
+
+// resizeSegments rebuilds the cache once the ideal segment size has drifted
+// to half or double the current one, which keeps the rebuild amortized. It
+// reports whether it rebuilt.
+func (l *LinkedList[T]) resizeSegments() bool {
+	if l.fixedPart {
+		return false
 	}
 
-	for i := indexNodes; i < len(Nodes); i++ {
-		if Nodes[i].Next != nil {
-			Nodes[i] = Nodes[i].Next
-		} else {
-			Nodes = Nodes[:i]
+	part := segmentSizeFor(l.length)
+	if part >= 2*l.part || 2*part <= l.part {
+		l.part = part
+		l.rebuildCache()
+		return true
+	}
+	return false
+}
+
+// rebuildCache starts a segment at every part-th node.
+func (l *LinkedList[T]) rebuildCache() {
+	segments := (l.length + l.part - 1) / l.part
+	l.nodes = make([]*Node[T], 0, segments)
+	l.starts = make([]uint, 0, segments)
+	var counter uint
+	for current := l.head; current != nil; current = current.Next {
+		if counter%l.part == 0 {
+			l.nodes = append(l.nodes, current)
+			l.starts = append(l.starts, counter)
 		}
+		counter++
+	}
+}
 
+// segmentOf returns the segment holding index, the last one starting at or
+// before it. The cache must not be empty.
+func (l *LinkedList[T]) segmentOf(index uint) int {
+	return sort.Search(len(l.starts), func(i int) bool { return l.starts[i] > index }) - 1
+}
+
+// segmentEnd returns the position after the last node of segment i.
+func (l *LinkedList[T]) segmentEnd(i int) uint {
+	if i+1 < len(l.starts) {
+		return l.starts[i+1]
 	}
+	return l.length
+}
 
+// seek returns the node at index, which must be below l.length. It starts
+// from the cached segment holding index, so it walks at most 2*part nodes.
+func (l *LinkedList[T]) seek(index uint) *Node[T] {
+	current, steps := l.head, index
+	if len(l.nodes) > 0 {
+		segment := l.segmentOf(index)
+		current, steps = l.nodes[segment], index-l.starts[segment]
+	}
+
+	for ; steps > 0; steps-- {
+		current = current.Next
+	}
+	return current
 }
 
-func (l *LinkedList) updateCacheForInsert(index uint, newNode *Node) {
-	partIndex := int(index / part)
-	if partIndex >= len(Nodes) {
-		Nodes = append(Nodes, newNode)
+// updateCacheForRemove must be called before the node at index is unlinked
+// and the length decremented. A segment starting at index now starts at
+// the next node, or is dropped if that node starts the next segment, and
+// every later segment starts one position earlier. A segment left small is
+// merged into a neighbour.
+func (l *LinkedList[T]) updateCacheForRemove(index uint) {
+	segment := l.segmentOf(index)
+	if l.starts[segment] == index {
+		next := l.nodes[segment].Next
+		if next == nil || segment+1 < len(l.nodes) && next == l.nodes[segment+1] {
+			l.deleteSegment(segment)
+			segment--
+		} else {
+			l.nodes[segment] = next
+		}
+	}
+	for i := segment + 1; i < len(l.starts); i++ {
+		l.starts[i]--
+	}
+
+	// The length is not decremented yet.
+	end := func(i int) uint {
+		if i+1 < len(l.starts) {
+			return l.starts[i+1]
+		}
+		return l.length - 1
+	}
+	switch {
+	case segment < 0:
+	case segment > 0 && end(segment)-l.starts[segment-1] <= l.part:
+		l.deleteSegment(segment)
+	case segment+1 < len(l.starts) && end(segment+1)-l.starts[segment] <= l.part:
+		l.deleteSegment(segment + 1)
+	}
+}
+
+// updateCacheForInsert must be called after newNode is linked at index and
+// the length incremented. The node joins the segment of the node before
+// it, or becomes the start of the first segment at index 0, so only the
+// later starts shift. A segment grown past 2*part is split in two.
+func (l *LinkedList[T]) updateCacheForInsert(index uint, newNode *Node[T]) {
+	if len(l.nodes) == 0 {
+		l.nodes = append(l.nodes, newNode)
+		l.starts = append(l.starts, 0)
 		return
 	}
 
-	var counter = index
-	newNode1 := newNode
-	for newNode1 != nil {
-		if counter%part == 0 {
-			if partIndex >= len(Nodes) {
-				Nodes = append(Nodes, newNode1)
-			} else {
-				Nodes[partIndex] = newNode1
-			}
-			partIndex++
+	segment := 0
+	if index == 0 {
+		l.nodes[0] = newNode
+	} else {
+		segment = l.segmentOf(index - 1)
+	}
+	for i := segment + 1; i < len(l.starts); i++ {
+		l.starts[i]++
+	}
+
+	if size := l.segmentEnd(segment) - l.starts[segment]; size > 2*l.part {
+		middle := l.nodes[segment]
+		for range size / 2 {
+			middle = middle.Next
 		}
-		counter++
-		newNode1 = newNode1.Next
+		l.nodes = slices.Insert(l.nodes, segment+1, middle)
+		l.starts = slices.Insert(l.starts, segment+1, l.starts[segment]+size/2)
 	}
 }
 
-func (l *LinkedList) HandleList() []int {
+func (l *LinkedList[T]) deleteSegment(i int) {
+	l.nodes = slices.Delete(l.nodes, i, i+1)
+	l.starts = slices.Delete(l.starts, i, i+1)
+}
+
+// refreshCacheFrom drops the segments starting at or after index and
+// starts new ones every part nodes by walking from node, the node now at
+// index.
+func (l *LinkedList[T]) refreshCacheFrom(index uint, node *Node[T]) {
+	keep := sort.Search(len(l.starts), func(i int) bool { return l.starts[i] >= index })
+	clear(l.nodes[keep:])
+	l.nodes = l.nodes[:keep]
+	l.starts = l.starts[:keep]
+
+	position := index
+	for current := node; current != nil; current = current.Next {
+		if len(l.starts) == 0 || position-l.starts[len(l.starts)-1] >= l.part {
+			l.nodes = append(l.nodes, current)
+			l.starts = append(l.starts, position)
+		}
+		position++
+	}
+}
+
+func (l *LinkedList[T]) HandleList() []T {
 	current := l.head
-	var values []int
+	var values []T
 	for current != nil {
 		values = append(values, current.Value)
 		current = current.Next
@@ -166,78 +408,28 @@ func (l *LinkedList) HandleList() []int {
 	return values
 }
 
-func (l *LinkedList) SearchConcurrently(ctx context.Context, cancel context.CancelFunc, find int) (int, bool) {
-	var result int
-	var isFound bool
-	var mu sync.Mutex
-	var wg sync.WaitGroup
-
-	for i := 0; i < len(Nodes); i++ {
-		wg.Add(1)
-		go func(i int) {
-			defer wg.Done()
-			index := Nodes[i]
-			for j := 0; j < int(part); j++ {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-				}
-
-				if index == nil {
-					return
-				}
-
-				if index.Value == find {
-					mu.Lock()
-					result = (i * int(part)) + j
-					isFound = true
-					mu.Unlock()
-					cancel()
-					return
-				}
-
-				if index.Next == nil {
-					return
-				}
-
-				select {
-				case <-ctx.Done():
-					return
-				default:
-					index = index.Next
-				}
-			}
-		}(i)
-	}
-
-	wg.Wait()
-	return result, isFound
-}
-
-func (l *LinkedList) SearchInSegmentedNodes(ctx context.Context, index int) (int, bool) {
-	indexStart := index / 10
-	if indexStart >= len(Nodes) {
+// SearchConcurrently returns the index of the first element equal to find,
+// scanning the segments on a bounded pool of workers. It reports false if
+// ctx is done before the search completes.
+func (l *LinkedList[T]) SearchConcurrently(ctx context.Context, find T) (int, bool) {
+	if !l.filter.mayContain(find) {
 		return 0, false
 	}
-
-	if index == 0 && len(Nodes) != 0 && Nodes[0].Value >= 0 {
-		return Nodes[0].Value, true
+	if l.index != nil {
+		index, found := l.Find(find)
+		return int(index), found
 	}
 
-	nodes := Nodes[indexStart]
-	targetIndex := index % 10
+	index, found := l.scan().first(ctx, func(v T) bool { return l.equal(v, find) })
+	return int(index), found
+}
 
-	for i := 0; i <= targetIndex; i++ {
-		if i == targetIndex && nodes.Value >= 0 {
-			return nodes.Value, true
-		}
-		if nodes.Next == nil {
-			return 0, false
-		}
-		nodes = nodes.Next
+// SearchInSegmentedNodes returns the element at index, walking at most one
+// segment from the cache. It reports false if ctx is already done.
+func (l *LinkedList[T]) SearchInSegmentedNodes(ctx context.Context, index int) (T, bool) {
+	if ctx.Err() != nil || index < 0 || uint(index) >= l.length {
+		var zero T
+		return zero, false
 	}
-
-	return 0, false
-
+	return l.seek(uint(index)).Value, true
 }
@@ -1,6 +1,8 @@
 package linkedlist
 
 import (
+	"context"
+	"sync"
 	"testing"
 	"testing/quick"
 )
@@ -174,3 +176,139 @@ func TestLinkedListFind(t *testing.T) {
 		}
 	}
 }
+
+func TestLinkedListGeneric(t *testing.T) {
+	l := New[string]()
+	l.Insert(0, "a")
+	l.Insert(1, "c")
+	l.Insert(1, "b")
+
+	if values := l.HandleList(); len(values) != 3 || values[0] != "a" || values[1] != "b" || values[2] != "c" {
+		t.Errorf("Expected [a b c], got %v", values)
+	}
+	if index, found := l.Find("c"); !found || index != 2 {
+		t.Errorf("Find(c): expected index 2, found true, got index %d, found %t", index, found)
+	}
+
+	type point struct {
+		labels []string
+		x, y   int
+	}
+	p := NewFunc(func(a, b point) bool { return a.x == b.x && a.y == b.y })
+	p.Insert(0, point{x: 1, y: 2})
+	p.Insert(1, point{labels: []string{"b"}, x: 3, y: 4})
+
+	if index, found := p.Find(point{x: 3, y: 4}); !found || index != 1 {
+		t.Errorf("Find(3,4): expected index 1, found true, got index %d, found %t", index, found)
+	}
+	if _, found := p.Find(point{x: 5, y: 6}); found {
+		t.Error("Find(5,6): expected not found")
+	}
+}
+
+func TestLinkedListSegmentCacheIsolation(t *testing.T) {
+	const n = 1000
+
+	lists := []*LinkedList[int]{
+		New[int](),
+		New[int](WithSegmentSize(7)),
+	}
+
+	var wg sync.WaitGroup
+	for k, l := range lists {
+		wg.Add(1)
+		go func(k int, l *LinkedList[int]) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				l.Insert(uint(i), i*(k+1))
+			}
+		}(k, l)
+	}
+	wg.Wait()
+
+	for k, l := range lists {
+		if err := l.Validate(); err != nil {
+			t.Errorf("list %d: %v", k, err)
+		}
+		for i := 0; i < n; i++ {
+			value, ok := l.SearchInSegmentedNodes(context.Background(), i)
+			if !ok || value != i*(k+1) {
+				t.Fatalf("list %d: SearchInSegmentedNodes(%d): expected %d, got %d, ok %t", k, i, i*(k+1), value, ok)
+			}
+		}
+	}
+}
+
+func TestLinkedListSegmentCacheQuick(t *testing.T) {
+	err := quick.Check(func(ops []uint16, part uint8) bool {
+		l := New[int](WithSegmentSize(uint(part%16) + 1))
+		var model []int
+
+		for k, op := range ops {
+			if op%3 == 0 && len(model) > 0 {
+				index := uint(op) % uint(len(model))
+				if !l.Remove(index) {
+					return false
+				}
+				model = append(model[:index], model[index+1:]...)
+			} else {
+				index := uint(op) % uint(len(model)+1)
+				if !l.Insert(index, k) {
+					return false
+				}
+				model = append(model[:index], append([]int{k}, model[index:]...)...)
+			}
+
+			if l.Validate() != nil {
+				return false
+			}
+		}
+
+		for k, v := range model {
+			if out, ok := l.Get(uint(k)); !ok || out != v {
+				return false
+			}
+		}
+		return true
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLinkedListAdaptiveSegments(t *testing.T) {
+	const n = 10_000
+	l := New[int]()
+
+	for i := 0; i < n; i++ {
+		l.Insert(uint(i), i)
+	}
+	if part := l.SegmentSize(); part < 50 || part > 200 {
+		t.Errorf("Expected segment size near 100 for %d elements, got %d", n, part)
+	}
+	if count, part := l.SegmentCount(), int(l.SegmentSize()); count < n/(2*part) || count > 2*n/part+1 {
+		t.Errorf("Expected about %d segments, got %d", n/part, count)
+	}
+
+	for i := n; i > 100; i-- {
+		l.Remove(0)
+	}
+	if part := l.SegmentSize(); part > 20 {
+		t.Errorf("Expected segment size near 10 for 100 elements, got %d", part)
+	}
+	for i := 0; i < 100; i++ {
+		value, ok := l.SearchInSegmentedNodes(context.Background(), i)
+		if !ok || value != n-100+i {
+			t.Fatalf("SearchInSegmentedNodes(%d): expected %d, got %d, ok %t", i, n-100+i, value, ok)
+		}
+	}
+
+	fixed := New[int](WithSegmentSize(7))
+	for i := 0; i < n; i++ {
+		fixed.Insert(uint(i), i)
+	}
+	if part := fixed.SegmentSize(); part != 7 {
+		t.Errorf("Expected fixed segment size 7, got %d", part)
+	}
+}
@@ -0,0 +1,644 @@
+// Package linkedlisttest checks that an implementation of linkedlist.List
+// behaves like the backends of package linkedlist.
+//
+// Test replays random sequences of operations against both the list and a
+// reference []int and fails at the first answer that differs. The optional
+// interfaces of package linkedlist that the list implements are part of the
+// sequence, and lists that are linkedlist.Concurrent are also run from many
+// goroutines at once.
+package linkedlisttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"testing/quick"
+
+	"linkedlist/linkedlist"
+)
+
+const (
+	// steps is the number of operations of a sequence.
+	steps = 200
+	// values bounds the values of the sequential suite, small enough that
+	// Find and the sorted operations meet duplicates.
+	values = 16
+	// workers and perWorker size a concurrent history.
+	workers   = 8
+	perWorker = 200
+)
+
+// Test runs the conformance suite against the lists newList returns. Every
+// call of newList must return a new, empty list.
+func Test(t *testing.T, newList func() linkedlist.List[int]) {
+	t.Run("Sequential", func(t *testing.T) {
+		count := 100
+		if testing.Short() {
+			count = 10
+		}
+		err := quick.Check(func(seed uint64) bool {
+			return runSequence(t, newList(), seed)
+		}, &quick.Config{MaxCount: count})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		if _, ok := newList().(linkedlist.Concurrent); !ok {
+			t.Skip("the list is not linkedlist.Concurrent")
+		}
+		t.Run("Append", func(t *testing.T) { testConcurrentAppend(t, newList()) })
+		t.Run("Mixed", func(t *testing.T) { testConcurrentMixed(t, newList()) })
+	})
+}
+
+// snapshot is a view taken by linkedlist.Snapshotter and the values it must
+// keep however the list changes later.
+type snapshot struct {
+	list  linkedlist.List[int]
+	model []int
+}
+
+// sequence is the state of one run of runSequence.
+type sequence struct {
+	t       *testing.T
+	rng     *rand.Rand
+	list    linkedlist.List[int]
+	model   []int
+	history []string
+
+	// less is the order of the last Sort, nil before the first one.
+	less      func(a, b int) bool
+	snapshots []snapshot
+}
+
+// runSequence applies steps random operations to list and reports whether
+// every answer matched the model. It logs the operations that led to the
+// first mismatch.
+func runSequence(t *testing.T, list linkedlist.List[int], seed uint64) bool {
+	s := &sequence{
+		t:    t,
+		rng:  rand.New(rand.NewPCG(seed, seed)),
+		list: list,
+	}
+	ops := s.ops()
+
+	if err := s.check(); err != nil {
+		t.Errorf("seed %d: new list: %v", seed, err)
+		return false
+	}
+	for step := range steps {
+		op := ops[s.rng.IntN(len(ops))]
+		if err := op(); err != nil {
+			t.Errorf("seed %d, step %d: %s: %v\nhistory:\n%s", seed, step, s.history[len(s.history)-1], err, s.tail())
+			return false
+		}
+		if uint(len(s.model)) != s.list.Len() {
+			t.Errorf("seed %d, step %d: %s: Len is %d, expected %d\nhistory:\n%s", seed, step, s.history[len(s.history)-1], s.list.Len(), len(s.model), s.tail())
+			return false
+		}
+		if step%10 == 9 || step == steps-1 {
+			if err := s.check(); err != nil {
+				t.Errorf("seed %d, step %d: %v\nhistory:\n%s", seed, step, err, s.tail())
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// tail returns the last operations of the history, one per line.
+func (s *sequence) tail() string {
+	history := s.history
+	if len(history) > 20 {
+		history = history[len(history)-20:]
+	}
+	return "\t" + strings.Join(history, "\n\t")
+}
+
+func (s *sequence) record(format string, args ...any) {
+	s.history = append(s.history, fmt.Sprintf(format, args...))
+}
+
+// index returns an index up to one past the end, so that about one in
+// len+2 operations is out of range.
+func (s *sequence) index() uint {
+	return uint(s.rng.IntN(len(s.model) + 2))
+}
+
+func (s *sequence) value() int {
+	return s.rng.IntN(values)
+}
+
+func (s *sequence) values() []int {
+	vals := make([]int, s.rng.IntN(8))
+	for i := range vals {
+		vals[i] = s.value()
+	}
+	return vals
+}
+
+// first returns the index of the first element of the model equal to val.
+func (s *sequence) first(val int) (uint, bool) {
+	i := slices.Index(s.model, val)
+	return uint(i), i >= 0
+}
+
+// ops returns the operations the list supports. Operations are repeated to
+// weight them.
+func (s *sequence) ops() []func() error {
+	ops := []func() error{
+		s.insert, s.insert, s.insert, s.remove, s.remove,
+		s.get, s.find, s.insertAll, s.appendValues, s.removeRange, s.slice,
+		s.getContext, s.findContext,
+	}
+	if _, ok := s.list.(linkedlist.Sorter[int]); ok {
+		ops = append(ops, s.sort, s.insertSorted, s.insertSorted, s.findSorted)
+	}
+	if _, ok := s.list.(linkedlist.SegmentSearcher[int]); ok {
+		ops = append(ops, s.searchConcurrently, s.searchInSegmentedNodes)
+	}
+	if _, ok := s.list.(linkedlist.Snapshotter[int]); ok {
+		ops = append(ops, s.snapshot)
+	}
+	if _, ok := s.list.(linkedlist.Validator); ok {
+		ops = append(ops, s.rebuildIndex)
+	}
+	return ops
+}
+
+func (s *sequence) insert() error {
+	index, val := s.index(), s.value()
+	s.record("Insert(%d, %d)", index, val)
+	want := index <= uint(len(s.model))
+	if ok := s.list.Insert(index, val); ok != want {
+		return fmt.Errorf("got %t, expected %t", ok, want)
+	}
+	if want {
+		s.model = slices.Insert(s.model, int(index), val)
+	}
+	return nil
+}
+
+func (s *sequence) remove() error {
+	index := s.index()
+	s.record("Remove(%d)", index)
+	want := index < uint(len(s.model))
+	if ok := s.list.Remove(index); ok != want {
+		return fmt.Errorf("got %t, expected %t", ok, want)
+	}
+	if want {
+		s.model = slices.Delete(s.model, int(index), int(index)+1)
+	}
+	return nil
+}
+
+func (s *sequence) get() error {
+	index := s.index()
+	s.record("Get(%d)", index)
+	val, ok := s.list.Get(index)
+	if want := index < uint(len(s.model)); ok != want {
+		return fmt.Errorf("got %t, expected %t", ok, want)
+	}
+	if ok && val != s.model[index] {
+		return fmt.Errorf("got %d, expected %d", val, s.model[index])
+	}
+	return nil
+}
+
+func (s *sequence) find() error {
+	val := s.value()
+	s.record("Find(%d)", val)
+	index, found := s.list.Find(val)
+	wantIndex, want := s.first(val)
+	if found != want || found && index != wantIndex {
+		return fmt.Errorf("got (%d, %t), expected (%d, %t)", index, found, wantIndex, want)
+	}
+	return nil
+}
+
+func (s *sequence) insertAll() error {
+	index, vals := s.index(), s.values()
+	s.record("InsertAll(%d, %v)", index, vals)
+	want := index <= uint(len(s.model))
+	if ok := linkedlist.InsertAll(s.list, index, vals); ok != want {
+		return fmt.Errorf("got %t, expected %t", ok, want)
+	}
+	if want {
+		s.model = slices.Insert(s.model, int(index), vals...)
+	}
+	return nil
+}
+
+func (s *sequence) appendValues() error {
+	vals := s.values()
+	s.record("Append(%v)", vals)
+	linkedlist.Append(s.list, vals...)
+	s.model = append(s.model, vals...)
+	return nil
+}
+
+func (s *sequence) removeRange() error {
+	from, to := s.index(), s.index()
+	s.record("RemoveRange(%d, %d)", from, to)
+	want := from <= to && to <= uint(len(s.model))
+	if ok := linkedlist.RemoveRange(s.list, from, to); ok != want {
+		return fmt.Errorf("got %t, expected %t", ok, want)
+	}
+	if want {
+		s.model = slices.Delete(s.model, int(from), int(to))
+	}
+	return nil
+}
+
+func (s *sequence) slice() error {
+	from, to := s.index(), s.index()
+	s.record("Slice(%d, %d)", from, to)
+	vals, ok := linkedlist.Slice(s.list, from, to)
+	want := from <= to && to <= uint(len(s.model))
+	if ok != want {
+		return fmt.Errorf("got %t, expected %t", ok, want)
+	}
+	if ok && !slices.Equal(vals, s.model[from:to]) {
+		return fmt.Errorf("got %v, expected %v", vals, s.model[from:to])
+	}
+	return nil
+}
+
+// done returns a context that is already canceled for about half of the
+// calls.
+func (s *sequence) done() (context.Context, bool) {
+	if s.rng.IntN(2) == 0 {
+		return context.Background(), false
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx, true
+}
+
+// getContext expects the answer of Get, or ErrCanceled if the context was
+// canceled.
+func (s *sequence) getContext() error {
+	ctx, canceled := s.done()
+	index := s.index()
+	s.record("GetContext(%d) canceled=%t", index, canceled)
+	val, err := linkedlist.GetContext(ctx, s.list, index)
+	if canceled && errors.Is(err, linkedlist.ErrCanceled) {
+		return nil
+	}
+	switch {
+	case index >= uint(len(s.model)):
+		if !errors.Is(err, linkedlist.ErrIndexOutOfRange) {
+			return fmt.Errorf("got error %v, expected ErrIndexOutOfRange", err)
+		}
+	case err != nil:
+		return fmt.Errorf("got error %v, expected %d", err, s.model[index])
+	case val != s.model[index]:
+		return fmt.Errorf("got %d, expected %d", val, s.model[index])
+	}
+	return nil
+}
+
+// findContext expects the answer of Find, or ErrCanceled if the context
+// was canceled.
+func (s *sequence) findContext() error {
+	ctx, canceled := s.done()
+	val := s.value()
+	s.record("FindContext(%d) canceled=%t", val, canceled)
+	index, err := linkedlist.FindContext(ctx, s.list, val)
+	if canceled && errors.Is(err, linkedlist.ErrCanceled) {
+		return nil
+	}
+	wantIndex, found := s.first(val)
+	switch {
+	case !found:
+		if !errors.Is(err, linkedlist.ErrNotFound) {
+			return fmt.Errorf("got error %v, expected ErrNotFound", err)
+		}
+	case err != nil:
+		return fmt.Errorf("got error %v, expected %d", err, wantIndex)
+	case index != wantIndex:
+		return fmt.Errorf("got %d, expected %d", index, wantIndex)
+	}
+	return nil
+}
+
+// sort sorts in ascending or descending order, so that InsertSorted and
+// FindSorted must keep the less function of the last Sort.
+func (s *sequence) sort() error {
+	less := func(a, b int) bool { return a < b }
+	name := "ascending"
+	if s.rng.IntN(2) == 0 {
+		less = func(a, b int) bool { return a > b }
+		name = "descending"
+	}
+	s.record("Sort(%s)", name)
+
+	s.list.(linkedlist.Sorter[int]).Sort(less)
+	s.less = less
+	s.sortModel()
+	return nil
+}
+
+// sortModel sorts the model stably by the less function of the last Sort.
+func (s *sequence) sortModel() {
+	slices.SortStableFunc(s.model, func(a, b int) int {
+		switch {
+		case s.less(a, b):
+			return -1
+		case s.less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	})
+}
+
+// insertSorted expects InsertSorted to fail and leave the list as it is
+// out of sorted mode, and otherwise to insert after every element that
+// does not order after val.
+func (s *sequence) insertSorted() error {
+	val := s.value()
+	s.record("InsertSorted(%d)", val)
+	sorter := s.list.(linkedlist.Sorter[int])
+	sorted := sorter.Sorted()
+	index, ok := sorter.InsertSorted(val)
+	if !sorted {
+		if ok {
+			return fmt.Errorf("got index %d out of sorted mode", index)
+		}
+		return nil
+	}
+
+	want := uint(len(s.model))
+	for i, v := range s.model {
+		if s.less(val, v) {
+			want = uint(i)
+			break
+		}
+	}
+	if !ok || index != want {
+		return fmt.Errorf("got (%d, %t), expected (%d, true)", index, ok, want)
+	}
+	s.model = slices.Insert(s.model, int(want), val)
+	return nil
+}
+
+// findSorted expects the first element equal to val, which is the first
+// equivalent element as well for ints.
+func (s *sequence) findSorted() error {
+	val := s.value()
+	s.record("FindSorted(%d)", val)
+	index, found := s.list.(linkedlist.Sorter[int]).FindSorted(val)
+	wantIndex, want := s.first(val)
+	if found != want || found && index != wantIndex {
+		return fmt.Errorf("got (%d, %t), expected (%d, %t)", index, found, wantIndex, want)
+	}
+	return nil
+}
+
+func (s *sequence) searchConcurrently() error {
+	val := s.value()
+	s.record("SearchConcurrently(%d)", val)
+	index, found := s.list.(linkedlist.SegmentSearcher[int]).SearchConcurrently(context.Background(), val)
+	wantIndex, want := s.first(val)
+	if found != want || found && uint(index) != wantIndex {
+		return fmt.Errorf("got (%d, %t), expected (%d, %t)", index, found, wantIndex, want)
+	}
+	return nil
+}
+
+func (s *sequence) searchInSegmentedNodes() error {
+	index := s.index()
+	s.record("SearchInSegmentedNodes(%d)", index)
+	val, ok := s.list.(linkedlist.SegmentSearcher[int]).SearchInSegmentedNodes(context.Background(), int(index))
+	if want := index < uint(len(s.model)); ok != want {
+		return fmt.Errorf("got %t, expected %t", ok, want)
+	}
+	if ok && val != s.model[index] {
+		return fmt.Errorf("got %d, expected %d", val, s.model[index])
+	}
+	return nil
+}
+
+// snapshot keeps the last few snapshots, which check compares with the
+// model of the moment they were taken.
+func (s *sequence) snapshot() error {
+	s.record("Snapshot()")
+	view := s.list.(linkedlist.Snapshotter[int]).Snapshot()
+	s.snapshots = append(s.snapshots, snapshot{list: view, model: slices.Clone(s.model)})
+	if len(s.snapshots) > 3 {
+		s.snapshots = s.snapshots[1:]
+	}
+	return nil
+}
+
+// rebuildIndex repairs a list that is not broken, which must not change it.
+func (s *sequence) rebuildIndex() error {
+	s.record("RebuildIndex()")
+	s.list.(linkedlist.Validator).RebuildIndex()
+	return s.check()
+}
+
+// check compares the whole list with the model, through every way of
+// reading it the list supports.
+func (s *sequence) check() error {
+	if err := equal(s.list, s.model); err != nil {
+		return err
+	}
+	for i, snap := range s.snapshots {
+		if err := equal(snap.list, snap.model); err != nil {
+			return fmt.Errorf("snapshot %d: %w", i, err)
+		}
+	}
+
+	if v, ok := s.list.(linkedlist.Validator); ok {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("Validate: %w", err)
+		}
+	}
+	if f, ok := s.list.(linkedlist.BloomFiltered[int]); ok {
+		for _, v := range s.model {
+			if !f.MayContain(v) {
+				return fmt.Errorf("MayContain(%d) is false for a value in the list", v)
+			}
+		}
+	}
+	if sorter, ok := s.list.(linkedlist.Sorter[int]); ok && sorter.Sorted() {
+		if s.less == nil {
+			return errors.New("Sorted is true before any Sort")
+		}
+		for i := 1; i < len(s.model); i++ {
+			if s.less(s.model[i], s.model[i-1]) {
+				return fmt.Errorf("Sorted is true for %v", s.model)
+			}
+		}
+	}
+	return nil
+}
+
+// equal reports how l differs from model, through Len, HandleList and All.
+func equal(l linkedlist.List[int], model []int) error {
+	if l.Len() != uint(len(model)) {
+		return fmt.Errorf("Len is %d, expected %d", l.Len(), len(model))
+	}
+	if vals := l.HandleList(); !slices.Equal(vals, model) && len(vals)+len(model) > 0 {
+		return fmt.Errorf("HandleList is %v, expected %v", vals, model)
+	}
+
+	var next uint
+	for i, v := range l.All() {
+		if i != next || i >= uint(len(model)) || v != model[i] {
+			return fmt.Errorf("All yields (%d, %d) at position %d of %v", i, v, next, model)
+		}
+		next++
+	}
+	if next != uint(len(model)) {
+		return fmt.Errorf("All yields %d elements, expected %d", next, len(model))
+	}
+	return nil
+}
+
+// value returns the n-th value of worker w in a concurrent history. Values
+// are distinct, so every one that is read tells who wrote it.
+func value(w, n int) int {
+	return w*perWorker + n
+}
+
+// owner returns the worker and position of a value written by value, and
+// false if no worker could have written it.
+func owner(v int) (w, n int, ok bool) {
+	if v < 0 || v >= workers*perWorker {
+		return 0, 0, false
+	}
+	return v / perWorker, v % perWorker, true
+}
+
+// testConcurrentAppend appends from every worker at once. No value may be
+// lost or duplicated, and the values of each worker must keep the order it
+// appended them in.
+func testConcurrentAppend(t *testing.T, list linkedlist.List[int]) {
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range perWorker {
+				linkedlist.Append(list, value(w, n))
+			}
+		}()
+	}
+	wg.Wait()
+
+	vals := list.HandleList()
+	if len(vals) != workers*perWorker || list.Len() != uint(len(vals)) {
+		t.Fatalf("Len is %d and HandleList holds %d values, expected %d", list.Len(), len(vals), workers*perWorker)
+	}
+	var next [workers]int
+	for i, v := range vals {
+		w, n, ok := owner(v)
+		if !ok {
+			t.Fatalf("value %d at index %d was never appended", v, i)
+		}
+		if n != next[w] {
+			t.Fatalf("value %d of worker %d at index %d, expected its value %d", n, w, i, next[w])
+		}
+		next[w]++
+	}
+}
+
+// testConcurrentMixed runs inserts, removes and reads from every worker at
+// once. Reads may only see values that were written, a single traversal
+// may not see one twice, and at the end the list must hold exactly the
+// values inserted and not removed.
+func testConcurrentMixed(t *testing.T, list linkedlist.List[int]) {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		inserted = make(map[int]bool)
+		removes  int
+		errs     = make(chan error, workers)
+	)
+	fail := func(format string, args ...any) {
+		select {
+		case errs <- fmt.Errorf(format, args...):
+		default:
+		}
+	}
+
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rng := rand.New(rand.NewPCG(uint64(w), 0))
+			for n := range perWorker {
+				switch rng.IntN(6) {
+				case 0, 1, 2:
+					v := value(w, n)
+					if list.Insert(uint(rng.IntN(int(list.Len())+1)), v) {
+						mu.Lock()
+						inserted[v] = true
+						mu.Unlock()
+					}
+				case 3:
+					if list.Remove(uint(rng.IntN(int(list.Len()) + 1))) {
+						mu.Lock()
+						removes++
+						mu.Unlock()
+					}
+				case 4:
+					index := uint(rng.IntN(int(list.Len()) + 1))
+					if v, ok := list.Get(index); ok {
+						if _, _, ok := owner(v); !ok {
+							fail("Get(%d) returned %d, which was never inserted", index, v)
+						}
+					}
+				case 5:
+					seen := make(map[int]bool)
+					var next uint
+					for i, v := range list.All() {
+						if i != next {
+							fail("All yields index %d at position %d", i, next)
+						}
+						if _, _, ok := owner(v); !ok || seen[v] {
+							fail("All yields %d, which was never inserted or was seen before", v)
+						}
+						seen[v] = true
+						next++
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	vals := list.HandleList()
+	if want := len(inserted) - removes; len(vals) != want {
+		t.Fatalf("the list holds %d values after %d inserts and %d removes", len(vals), len(inserted), removes)
+	}
+	if list.Len() != uint(len(vals)) {
+		t.Fatalf("Len is %d, HandleList holds %d values", list.Len(), len(vals))
+	}
+	seen := make(map[int]bool)
+	for _, v := range vals {
+		if !inserted[v] || seen[v] {
+			t.Fatalf("the list holds %d, which was not inserted or is held twice", v)
+		}
+		seen[v] = true
+	}
+	if v, ok := list.(linkedlist.Validator); ok {
+		if err := v.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package linkedlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// List is the set of operations the v1 and v2 APIs need from a backend.
+type List[T any] interface {
+	Insert(index uint, val T) bool
+	Remove(index uint) bool
+	Get(index uint) (T, bool)
+	Find(val T) (index uint, found bool)
+	HandleList() []T
+	Len() uint
+	All() iter.Seq2[uint, T]
+}
+
+// SegmentSearcher is implemented by backends that keep a segment cache and
+// can search it on a pool of workers, one segment at a time.
+type SegmentSearcher[T any] interface {
+	SearchConcurrently(ctx context.Context, find T) (int, bool)
+	SearchInSegmentedNodes(ctx context.Context, index int) (T, bool)
+}
+
+// Segmented is implemented by backends that report their segment cache layout.
+type Segmented interface {
+	SegmentSize() uint
+	SegmentCount() int
+}
+
+// BulkList is implemented by backends that insert and remove many elements
+// in a single traversal.
+type BulkList[T any] interface {
+	InsertAll(index uint, values []T) bool
+	Append(values ...T)
+	RemoveRange(from, to uint) bool
+	Slice(from, to uint) ([]T, bool)
+}
+
+// Sorter is implemented by backends that can order themselves and keep a
+// sorted mode.
+type Sorter[T any] interface {
+	Sort(less func(a, b T) bool)
+	Sorted() bool
+	InsertSorted(val T) (uint, bool)
+	FindSorted(val T) (index uint, found bool)
+}
+
+// Concurrent is implemented by backends that are safe for concurrent use
+// without an external lock.
+type Concurrent interface {
+	ConcurrentSafe()
+}
+
+// Snapshotter is implemented by backends that hand out an immutable view
+// of their current contents in O(1), which can be read without any lock.
+type Snapshotter[T any] interface {
+	Snapshot() List[T]
+}
+
+// BloomFiltered is implemented by backends that can keep a Bloom filter of
+// their values. MayContain is safe to call without the lock that guards
+// the list.
+type BloomFiltered[T any] interface {
+	MayContain(val T) bool
+	FilterSize() uint
+	FalsePositiveRate() float64
+}
+
+// appender is the part of BulkList that LockFreeList also has.
+type appender[T any] interface {
+	Append(values ...T)
+}
+
+// Backend names accepted by NewBackend.
+const (
+	BackendLinkedList   = "linkedlist"
+	BackendDoubly       = "doubly"
+	BackendSkipList     = "skiplist"
+	BackendUnrolled     = "unrolled"
+	BackendTreap        = "treap"
+	BackendLockFree     = "lockfree"
+	BackendLockCoupling = "lockcoupling"
+	BackendPersistent   = "persistent"
+)
+
+// Compile-time checks of the interfaces each backend implements, by
+// backend in the order of the names above.
+var (
+	_ List[int]            = (*LinkedList[int])(nil)
+	_ SegmentSearcher[int] = (*LinkedList[int])(nil)
+	_ BulkList[int]        = (*LinkedList[int])(nil)
+	_ Sorter[int]          = (*LinkedList[int])(nil)
+	_ BloomFiltered[int]   = (*LinkedList[int])(nil)
+	_ Bounded              = (*LinkedList[int])(nil)
+	_ ContextList[int]     = (*LinkedList[int])(nil)
+	_ Validator            = (*LinkedList[int])(nil)
+
+	_ List[int]            = (*DoublyLinkedList[int])(nil)
+	_ SegmentSearcher[int] = (*DoublyLinkedList[int])(nil)
+	_ BulkList[int]        = (*DoublyLinkedList[int])(nil)
+	_ ContextList[int]     = (*DoublyLinkedList[int])(nil)
+	_ Validator            = (*DoublyLinkedList[int])(nil)
+
+	_ List[int]        = (*SkipList[int])(nil)
+	_ ContextList[int] = (*SkipList[int])(nil)
+
+	_ List[int]        = (*UnrolledList[int])(nil)
+	_ ContextList[int] = (*UnrolledList[int])(nil)
+
+	_ List[int]        = (*Treap[int])(nil)
+	_ Sorter[int]      = (*Treap[int])(nil)
+	_ ContextList[int] = (*Treap[int])(nil)
+
+	_ List[int]        = (*LockFreeList[int])(nil)
+	_ Concurrent       = (*LockFreeList[int])(nil)
+	_ ContextList[int] = (*LockFreeList[int])(nil)
+
+	_ List[int]        = (*LockCouplingList[int])(nil)
+	_ Concurrent       = (*LockCouplingList[int])(nil)
+	_ ContextList[int] = (*LockCouplingList[int])(nil)
+
+	_ List[int]        = (*PersistentList[int])(nil)
+	_ BulkList[int]    = (*PersistentList[int])(nil)
+	_ Concurrent       = (*PersistentList[int])(nil)
+	_ Snapshotter[int] = (*PersistentList[int])(nil)
+	_ ContextList[int] = (*PersistentList[int])(nil)
+)
+
+// NewBackend returns an empty int list stored in the named backend. An
+// empty name selects BackendLinkedList, which takes every option. The
+// other backends take only some of them, and NewBackend returns an error
+// wrapping errors.ErrUnsupported for an option the backend would ignore.
+func NewBackend(name string, opts ...Option) (List[int], error) {
+	var l List[int]
+	var supported []string
+	switch name {
+	case "", BackendLinkedList:
+		return New[int](opts...), nil
+	case BackendDoubly:
+		l, supported = NewDoubly[int](opts...), []string{"WithSegmentSize", "WithScanWorkers"}
+	case BackendSkipList:
+		l = NewSkipList[int]()
+	case BackendUnrolled:
+		l = NewUnrolled[int]()
+	case BackendTreap:
+		l = NewTreap[int]()
+	case BackendLockFree:
+		l = NewLockFree[int]()
+	case BackendLockCoupling:
+		l, supported = NewLockCoupling[int](opts...), []string{"WithSegmentSize"}
+	case BackendPersistent:
+		l = NewPersistent[int]()
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", name)
+	}
+	for _, opt := range newOptions(opts).names() {
+		if !slices.Contains(supported, opt) {
+			return nil, fmt.Errorf("storage backend %q does not support %s: %w", name, opt, errors.ErrUnsupported)
+		}
+	}
+	return l, nil
+}
+
+// InsertAll inserts values into l starting at index, in one traversal when
+// l is a BulkList and one Insert per value otherwise. Without BulkList it
+// stops at the first Insert that fails, which another writer can cause,
+// keeping the values inserted before it.
+func InsertAll[T any](l List[T], index uint, values []T) bool {
+	if b, ok := l.(BulkList[T]); ok {
+		return b.InsertAll(index, values)
+	}
+	if index > l.Len() {
+		return false
+	}
+	for i, val := range values {
+		if !l.Insert(index+uint(i), val) {
+			return false
+		}
+	}
+	return true
+}
+
+// Append inserts values at the end of l.
+func Append[T any](l List[T], values ...T) {
+	if b, ok := l.(appender[T]); ok {
+		b.Append(values...)
+		return
+	}
+	InsertAll(l, l.Len(), values)
+}
+
+// RemoveRange removes the elements of l in [from, to). Without BulkList it
+// stops at the first Remove that fails, keeping the removals before it.
+func RemoveRange[T any](l List[T], from, to uint) bool {
+	if b, ok := l.(BulkList[T]); ok {
+		return b.RemoveRange(from, to)
+	}
+	if from > to || to > l.Len() {
+		return false
+	}
+	for i := from; i < to; i++ {
+		if !l.Remove(from) {
+			return false
+		}
+	}
+	return true
+}
+
+// Slice returns a copy of the values of l in [from, to).
+func Slice[T any](l List[T], from, to uint) ([]T, bool) {
+	if b, ok := l.(BulkList[T]); ok {
+		return b.Slice(from, to)
+	}
+	if from > to || to > l.Len() {
+		return nil, false
+	}
+	values := make([]T, 0, to-from)
+	for i, val := range l.All() {
+		if i >= to {
+			break
+		}
+		if i >= from {
+			values = append(values, val)
+		}
+	}
+	return values, true
+}
@@ -0,0 +1,46 @@
+package linkedlist
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewBackend(t *testing.T) {
+	for _, name := range []string{"", BackendLinkedList, BackendDoubly, BackendSkipList, BackendUnrolled, BackendTreap, BackendLockFree, BackendLockCoupling, BackendPersistent} {
+		l, err := NewBackend(name)
+		if err != nil {
+			t.Fatalf("NewBackend(%q): unexpected error %v", name, err)
+		}
+		if !l.Insert(0, 10) || !l.Insert(1, 30) || !l.Insert(1, 20) {
+			t.Errorf("NewBackend(%q): Insert failed", name)
+		}
+		if l.Len() != 3 {
+			t.Errorf("NewBackend(%q): expected length 3, got %d", name, l.Len())
+		}
+		if index, found := l.Find(20); !found || index != 1 {
+			t.Errorf("NewBackend(%q): Find(20): expected index 1, got index %d, found %t", name, index, found)
+		}
+	}
+
+	if _, err := NewBackend("array"); err == nil {
+		t.Error("NewBackend did not fail for an unknown backend")
+	}
+
+	if _, err := NewBackend(BackendLinkedList, WithSegmentSize(8), WithScanWorkers(2), WithCapacity(10), WithValueIndex(), WithBloomFilter(10, 0.01)); err != nil {
+		t.Errorf("NewBackend(%q): unexpected error %v with every option", BackendLinkedList, err)
+	}
+	if _, err := NewBackend(BackendDoubly, WithSegmentSize(8), WithScanWorkers(2)); err != nil {
+		t.Errorf("NewBackend(%q): unexpected error %v", BackendDoubly, err)
+	}
+	for name, opt := range map[string]Option{
+		BackendDoubly:       WithValueIndex(),
+		BackendSkipList:     WithBloomFilter(10, 0.01),
+		BackendLockFree:     WithCapacity(10),
+		BackendLockCoupling: WithScanWorkers(2),
+		BackendPersistent:   WithSegmentSize(8),
+	} {
+		if _, err := NewBackend(name, opt); !errors.Is(err, errors.ErrUnsupported) {
+			t.Errorf("NewBackend(%q): expected errors.ErrUnsupported for an ignored option, got %v", name, err)
+		}
+	}
+}
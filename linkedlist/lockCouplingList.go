@@ -0,0 +1,224 @@
+package linkedlist
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// lockCouplingSegmentSize is the number of values a segment holds when no
+// WithSegmentSize option is given.
+const lockCouplingSegmentSize uint = 64
+
+type lockedSegment[T any] struct {
+	mutex  sync.RWMutex
+	values []T
+	next   *lockedSegment[T]
+}
+
+// LockCouplingList is a list of T split into segments that each have their
+// own lock, so it is safe for concurrent use without an external lock.
+// Every operation walks the segments hand over hand: it locks the next
+// segment before it unlocks the current one. Readers take read locks and
+// writers take write locks, so operations on different segments run in
+// parallel, readers share segments, and no operation overtakes a writer.
+//
+// Segments split in two when an insert finds them full and merge with
+// their successor when they drop below half full, like UnrolledList.
+type LockCouplingList[T any] struct {
+	// head is a sentinel segment that never holds values.
+	head   *lockedSegment[T]
+	length atomic.Int64
+	size   int
+	equal  func(a, b T) bool
+}
+
+// NewLockCoupling returns an empty lock coupling list that compares values
+// with ==. WithSegmentSize sets the number of values per segment.
+func NewLockCoupling[T comparable](opts ...Option) *LockCouplingList[T] {
+	return NewLockCouplingFunc(func(a, b T) bool { return a == b }, opts...)
+}
+
+// NewLockCouplingFunc returns an empty lock coupling list that compares
+// values with equal.
+func NewLockCouplingFunc[T any](equal func(a, b T) bool, opts ...Option) *LockCouplingList[T] {
+	o := newOptions(opts)
+	size := lockCouplingSegmentSize
+	if o.fixedPart {
+		size = max(o.part, 2)
+	}
+	return &LockCouplingList[T]{
+		head:  &lockedSegment[T]{},
+		size:  int(size),
+		equal: equal,
+	}
+}
+
+// ConcurrentSafe marks the list as safe without an external lock.
+func (l *LockCouplingList[T]) ConcurrentSafe() {}
+
+func (l *LockCouplingList[T]) Len() uint {
+	return uint(l.length.Load())
+}
+
+func (l *LockCouplingList[T]) Find(val T) (index uint, found bool) {
+	for i, v := range l.All() {
+		if l.equal(v, val) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (l *LockCouplingList[T]) Get(index uint) (T, bool) {
+	prev := l.head
+	prev.mutex.RLock()
+	for seg := prev.next; seg != nil; seg = seg.next {
+		seg.mutex.RLock()
+		prev.mutex.RUnlock()
+		prev = seg
+
+		if index < uint(len(seg.values)) {
+			val := seg.values[index]
+			seg.mutex.RUnlock()
+			return val, true
+		}
+		index -= uint(len(seg.values))
+	}
+	prev.mutex.RUnlock()
+
+	var zero T
+	return zero, false
+}
+
+func (l *LockCouplingList[T]) Insert(index uint, val T) bool {
+	prev := l.head
+	prev.mutex.Lock()
+	for seg := prev.next; seg != nil; seg = seg.next {
+		seg.mutex.Lock()
+		prev.mutex.Unlock()
+		prev = seg
+
+		n := uint(len(seg.values))
+		if index < n || (index == n && len(seg.values) < l.size) {
+			l.insertInto(seg, int(index), val)
+			seg.mutex.Unlock()
+			return true
+		}
+		index -= n
+	}
+	defer prev.mutex.Unlock()
+
+	if index != 0 {
+		return false
+	}
+	seg := &lockedSegment[T]{values: make([]T, 0, l.size)}
+	seg.values = append(seg.values, val)
+	prev.next = seg
+	l.length.Add(1)
+	return true
+}
+
+// insertInto inserts val at offset in the locked segment, splitting it
+// first when it is full. The new half needs no lock of its own: it is only
+// reachable through seg.
+func (l *LockCouplingList[T]) insertInto(seg *lockedSegment[T], offset int, val T) {
+	if len(seg.values) == l.size {
+		half := l.size / 2
+		next := &lockedSegment[T]{values: make([]T, 0, l.size), next: seg.next}
+		next.values = append(next.values, seg.values[half:]...)
+		clear(seg.values[half:])
+		seg.values = seg.values[:half]
+		seg.next = next
+
+		if offset > half {
+			seg, offset = next, offset-half
+		}
+	}
+
+	var zero T
+	seg.values = append(seg.values, zero)
+	copy(seg.values[offset+1:], seg.values[offset:])
+	seg.values[offset] = val
+	l.length.Add(1)
+}
+
+func (l *LockCouplingList[T]) Remove(index uint) bool {
+	prev := l.head
+	prev.mutex.Lock()
+	for seg := prev.next; seg != nil; seg = seg.next {
+		seg.mutex.Lock()
+		if index < uint(len(seg.values)) {
+			l.removeFrom(prev, seg, int(index))
+			seg.mutex.Unlock()
+			prev.mutex.Unlock()
+			return true
+		}
+		index -= uint(len(seg.values))
+
+		prev.mutex.Unlock()
+		prev = seg
+	}
+	prev.mutex.Unlock()
+	return false
+}
+
+// removeFrom removes the value at offset in seg. prev and seg must be
+// locked, which also keeps every other operation out of seg.next.
+func (l *LockCouplingList[T]) removeFrom(prev, seg *lockedSegment[T], offset int) {
+	last := len(seg.values) - 1
+	copy(seg.values[offset:], seg.values[offset+1:])
+	var zero T
+	seg.values[last] = zero
+	seg.values = seg.values[:last]
+	l.length.Add(-1)
+
+	if len(seg.values) == 0 {
+		prev.next = seg.next
+		return
+	}
+
+	next := seg.next
+	if next == nil || len(seg.values) >= l.size/2 {
+		return
+	}
+	next.mutex.Lock()
+	if len(seg.values)+len(next.values) <= l.size {
+		seg.values = append(seg.values, next.values...)
+		seg.next = next.next
+	}
+	next.mutex.Unlock()
+}
+
+func (l *LockCouplingList[T]) HandleList() []T {
+	var values []T
+	for _, v := range l.All() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// All yields every index and value in list order. It holds a read lock on
+// one segment while yielding, so the loop body must not modify the list.
+func (l *LockCouplingList[T]) All() iter.Seq2[uint, T] {
+	return func(yield func(uint, T) bool) {
+		index := uint(0)
+
+		prev := l.head
+		prev.mutex.RLock()
+		for seg := prev.next; seg != nil; seg = seg.next {
+			seg.mutex.RLock()
+			prev.mutex.RUnlock()
+			prev = seg
+
+			for _, v := range seg.values {
+				if !yield(index, v) {
+					seg.mutex.RUnlock()
+					return
+				}
+				index++
+			}
+		}
+		prev.mutex.RUnlock()
+	}
+}
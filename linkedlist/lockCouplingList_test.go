@@ -0,0 +1,102 @@
+package linkedlist
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"testing/quick"
+)
+
+// checkLockedSegments reports whether no segment of l is empty or holds
+// more than the segment size.
+func checkLockedSegments(l *LockCouplingList[int]) bool {
+	for seg := l.head.next; seg != nil; seg = seg.next {
+		if len(seg.values) == 0 || len(seg.values) > l.size {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLockCouplingPropertiesQuick(t *testing.T) {
+	err := quick.Check(func(ops []uint16) bool {
+		l := NewLockCoupling[int](WithSegmentSize(4))
+		var model []int
+
+		for k, op := range ops {
+			if op%3 == 0 && len(model) > 0 {
+				index := uint(op) % uint(len(model))
+				if !l.Remove(index) {
+					return false
+				}
+				model = slices.Delete(model, int(index), int(index)+1)
+			} else {
+				index := uint(op) % uint(len(model)+1)
+				if !l.Insert(index, k) {
+					return false
+				}
+				model = slices.Insert(model, int(index), k)
+			}
+
+			if l.Len() != uint(len(model)) || !checkLockedSegments(l) {
+				return false
+			}
+		}
+
+		for k, v := range model {
+			if out, ok := l.Get(uint(k)); !ok || out != v {
+				return false
+			}
+			if index, found := l.Find(v); !found || index != uint(k) {
+				return false
+			}
+		}
+		return slices.Equal(l.HandleList(), model) && !l.Insert(uint(len(model))+1, 0) && !l.Remove(uint(len(model)))
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestLockCouplingStress runs writers at both ends and readers across the
+// list at once. Run it with -race.
+func TestLockCouplingStress(t *testing.T) {
+	const (
+		workers = 8
+		rounds  = 500
+	)
+
+	l := NewLockCoupling[int](WithSegmentSize(8))
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range rounds {
+				v := w*rounds + i
+				switch i % 4 {
+				case 0, 1:
+					l.Insert(l.Len(), v)
+				case 2:
+					l.Insert(0, v)
+				case 3:
+					l.Remove(uint(i) % (l.Len() + 1))
+				}
+				l.Get(uint(i))
+				l.Find(v)
+			}
+		}()
+	}
+	wg.Wait()
+
+	values := l.HandleList()
+	if l.Len() != uint(len(values)) || !checkLockedSegments(l) {
+		t.Fatalf("expected length %d, got %d", len(values), l.Len())
+	}
+
+	slices.Sort(values)
+	if len(slices.Compact(values)) != len(l.HandleList()) {
+		t.Error("list holds duplicate values")
+	}
+}
@@ -0,0 +1,269 @@
+package linkedlist
+
+import (
+	"iter"
+	"sync/atomic"
+)
+
+// LockFreeNode is an element of a LockFreeList. Its value never changes
+// once the node is linked.
+type LockFreeNode[T any] struct {
+	Value T
+	next  atomic.Pointer[lockFreeLink[T]]
+}
+
+// lockFreeLink is the next pointer of a node together with the mark that
+// says the node itself has been removed. Both are replaced in a single
+// compare-and-swap, which is what a marked pointer does in C.
+type lockFreeLink[T any] struct {
+	node   *LockFreeNode[T]
+	marked bool
+}
+
+// LockFreeList is a Harris-style linked list that is safe for concurrent
+// use without a lock. Removing a node first marks its next link, which
+// stops any insert after it, and then unlinks it; operations that walk the
+// list unlink the marked nodes they pass.
+//
+// Every operation is atomic on the nodes it touches, but indices are only
+// a snapshot: under concurrent writes, the element at an index may change
+// between two calls. Len may briefly count elements whose insert is still
+// in progress.
+type LockFreeList[T any] struct {
+	head   *LockFreeNode[T]
+	tail   atomic.Pointer[LockFreeNode[T]]
+	length atomic.Int64
+	equal  func(a, b T) bool
+}
+
+// NewLockFree returns an empty lock-free list that compares values with ==.
+func NewLockFree[T comparable]() *LockFreeList[T] {
+	return NewLockFreeFunc(func(a, b T) bool { return a == b })
+}
+
+// NewLockFreeFunc returns an empty lock-free list that compares values with
+// equal.
+func NewLockFreeFunc[T any](equal func(a, b T) bool) *LockFreeList[T] {
+	l := &LockFreeList[T]{
+		head:  &LockFreeNode[T]{},
+		equal: equal,
+	}
+	l.head.next.Store(&lockFreeLink[T]{})
+	l.tail.Store(l.head)
+	return l
+}
+
+// ConcurrentSafe marks the list as safe without an external lock.
+func (l *LockFreeList[T]) ConcurrentSafe() {}
+
+func (l *LockFreeList[T]) Len() uint {
+	return uint(l.length.Load())
+}
+
+func (l *LockFreeList[T]) Find(val T) (index uint, found bool) {
+	for i, v := range l.All() {
+		if l.equal(v, val) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// FindNode returns the first node holding val, or nil.
+func (l *LockFreeList[T]) FindNode(val T) *LockFreeNode[T] {
+	for node := range l.nodes() {
+		if l.equal(node.Value, val) {
+			return node
+		}
+	}
+	return nil
+}
+
+func (l *LockFreeList[T]) Get(index uint) (T, bool) {
+	for i, v := range l.All() {
+		if i == index {
+			return v, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+func (l *LockFreeList[T]) Insert(index uint, val T) bool {
+	node := &LockFreeNode[T]{Value: val}
+	l.length.Add(1)
+	for {
+		pred, link, at := l.search(func(i uint, _ *LockFreeNode[T]) bool { return i == index })
+		if at != index {
+			l.length.Add(-1)
+			return false
+		}
+
+		node.next.Store(&lockFreeLink[T]{node: link.node})
+		if pred.next.CompareAndSwap(link, &lockFreeLink[T]{node: node}) {
+			if link.node == nil {
+				l.tail.Store(node)
+			}
+			return true
+		}
+	}
+}
+
+// InsertAfter links a new node holding val right after node, or at the
+// front of the list if node is nil. It returns the new node, or nil if
+// node has been removed.
+func (l *LockFreeList[T]) InsertAfter(node *LockFreeNode[T], val T) *LockFreeNode[T] {
+	if node == nil {
+		node = l.head
+	}
+
+	inserted := &LockFreeNode[T]{Value: val}
+	l.length.Add(1)
+	for {
+		link := node.next.Load()
+		if link.marked {
+			l.length.Add(-1)
+			return nil
+		}
+
+		inserted.next.Store(&lockFreeLink[T]{node: link.node})
+		if node.next.CompareAndSwap(link, &lockFreeLink[T]{node: inserted}) {
+			return inserted
+		}
+	}
+}
+
+// Append links values at the end of the list. They are linked with a
+// single compare-and-swap, so no concurrent insert lands between them.
+func (l *LockFreeList[T]) Append(values ...T) {
+	if len(values) == 0 {
+		return
+	}
+
+	first := &LockFreeNode[T]{Value: values[0]}
+	last := first
+	for _, val := range values[1:] {
+		node := &LockFreeNode[T]{Value: val}
+		last.next.Store(&lockFreeLink[T]{node: node})
+		last = node
+	}
+	last.next.Store(&lockFreeLink[T]{})
+	l.length.Add(int64(len(values)))
+
+	// tail is only a hint: it may lag behind the last node, or point at a
+	// removed one, in which case the walk restarts from head.
+	node := l.tail.Load()
+	for {
+		link := node.next.Load()
+		switch {
+		case link.marked:
+			node = l.head
+		case link.node != nil:
+			node = link.node
+		case node.next.CompareAndSwap(link, &lockFreeLink[T]{node: first}):
+			l.tail.Store(last)
+			return
+		}
+	}
+}
+
+func (l *LockFreeList[T]) Remove(index uint) bool {
+	for {
+		pred, link, _ := l.search(func(i uint, _ *LockFreeNode[T]) bool { return i == index })
+		if link.node == nil {
+			return false
+		}
+		if l.mark(link.node) {
+			pred.next.CompareAndSwap(link, &lockFreeLink[T]{node: link.node.next.Load().node})
+			return true
+		}
+	}
+}
+
+// Delete removes node from the list. It returns false if node is nil, as
+// FindNode returns for a missing value, or was already removed.
+func (l *LockFreeList[T]) Delete(node *LockFreeNode[T]) bool {
+	if node == nil || !l.mark(node) {
+		return false
+	}
+	l.search(func(_ uint, n *LockFreeNode[T]) bool { return n == node })
+	return true
+}
+
+// mark flags node as removed and reports whether this call did so.
+func (l *LockFreeList[T]) mark(node *LockFreeNode[T]) bool {
+	for {
+		link := node.next.Load()
+		if link.marked {
+			return false
+		}
+		if node.next.CompareAndSwap(link, &lockFreeLink[T]{node: link.node, marked: true}) {
+			l.length.Add(-1)
+			return true
+		}
+	}
+}
+
+// search walks the list, unlinking the marked nodes it passes, until stop
+// returns true for a live node or the list ends. It returns the node before
+// that position, the link read from it and the position. The link's node
+// is nil at the end of the list.
+func (l *LockFreeList[T]) search(stop func(index uint, node *LockFreeNode[T]) bool) (pred *LockFreeNode[T], link *lockFreeLink[T], index uint) {
+retry:
+	for {
+		pred, index = l.head, 0
+		link = pred.next.Load()
+		for link.node != nil {
+			next := link.node.next.Load()
+			if next.marked {
+				unlinked := &lockFreeLink[T]{node: next.node}
+				if !pred.next.CompareAndSwap(link, unlinked) {
+					continue retry
+				}
+				link = unlinked
+				continue
+			}
+
+			if stop(index, link.node) {
+				break
+			}
+			pred, link = link.node, next
+			index++
+		}
+		return pred, link, index
+	}
+}
+
+func (l *LockFreeList[T]) HandleList() []T {
+	var values []T
+	for _, v := range l.All() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// All yields every index and value that is not removed when the walk
+// reaches it. It is safe to call concurrently with modifications.
+func (l *LockFreeList[T]) All() iter.Seq2[uint, T] {
+	return func(yield func(uint, T) bool) {
+		index := uint(0)
+		for node := range l.nodes() {
+			if !yield(index, node.Value) {
+				return
+			}
+			index++
+		}
+	}
+}
+
+func (l *LockFreeList[T]) nodes() iter.Seq[*LockFreeNode[T]] {
+	return func(yield func(*LockFreeNode[T]) bool) {
+		for node := l.head.next.Load().node; node != nil; {
+			link := node.next.Load()
+			if !link.marked && !yield(node) {
+				return
+			}
+			node = link.node
+		}
+	}
+}
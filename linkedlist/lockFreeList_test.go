@@ -0,0 +1,134 @@
+package linkedlist
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/quick"
+)
+
+func TestLockFreePropertiesQuick(t *testing.T) {
+	err := quick.Check(func(ops []uint16) bool {
+		l := NewLockFree[int]()
+		var model []int
+
+		for k, op := range ops {
+			switch {
+			case op%4 == 0 && len(model) > 0:
+				index := uint(op) % uint(len(model))
+				if !l.Remove(index) {
+					return false
+				}
+				model = slices.Delete(model, int(index), int(index)+1)
+			case op%4 == 1 && len(model) > 0:
+				// FindNode returns the first copy of a value.
+				index := slices.Index(model, model[int(op)%len(model)])
+				if !l.Delete(l.FindNode(model[index])) {
+					return false
+				}
+				model = slices.Delete(model, index, index+1)
+			case op%4 == 2:
+				l.Append(k, -k)
+				model = append(model, k, -k)
+			default:
+				index := uint(op) % uint(len(model)+1)
+				if !l.Insert(index, k) {
+					return false
+				}
+				model = slices.Insert(model, int(index), k)
+			}
+
+			if l.Len() != uint(len(model)) {
+				return false
+			}
+		}
+
+		for k, v := range model {
+			if out, ok := l.Get(uint(k)); !ok || out != v {
+				return false
+			}
+		}
+		return slices.Equal(l.HandleList(), model) && !l.Insert(uint(len(model))+1, 0) && !l.Remove(uint(len(model)))
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLockFreeInsertAfter(t *testing.T) {
+	l := NewLockFree[int]()
+	first := l.InsertAfter(nil, 1)
+	third := l.InsertAfter(first, 3)
+	l.InsertAfter(first, 2)
+
+	if got := l.HandleList(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("expected [1 2 3], got %v", got)
+	}
+
+	if !l.Delete(third) || l.Delete(third) {
+		t.Error("Delete must succeed exactly once")
+	}
+	if l.Delete(l.FindNode(-1)) {
+		t.Error("Delete of a missing value succeeded")
+	}
+	if l.InsertAfter(third, 4) != nil {
+		t.Error("InsertAfter a removed node succeeded")
+	}
+	if got := l.HandleList(); !slices.Equal(got, []int{1, 2}) || l.Len() != 2 {
+		t.Errorf("expected [1 2], got %v with length %d", got, l.Len())
+	}
+}
+
+// TestLockFreeStress mixes every write with concurrent readers. Run it
+// with -race.
+func TestLockFreeStress(t *testing.T) {
+	const (
+		workers = 8
+		rounds  = 500
+	)
+
+	l := NewLockFree[int]()
+	var removed atomic.Int64
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range rounds {
+				v := w*rounds + i
+				switch i % 4 {
+				case 0:
+					l.Append(v)
+				case 1:
+					l.Insert(0, v)
+				case 2:
+					if node := l.FindNode(v - 2); node != nil {
+						l.InsertAfter(node, v)
+					} else {
+						l.Append(v)
+					}
+				case 3:
+					if l.Remove(uint(i) % (l.Len() + 1)) {
+						removed.Add(1)
+					}
+					l.Append(v)
+				}
+				l.Get(uint(i))
+				l.Find(v)
+			}
+		}()
+	}
+	wg.Wait()
+
+	values := l.HandleList()
+	if want := workers*rounds - int(removed.Load()); len(values) != want || l.Len() != uint(want) {
+		t.Fatalf("expected %d elements, got %d with length %d", want, len(values), l.Len())
+	}
+
+	slices.Sort(values)
+	if len(slices.Compact(values)) != len(l.HandleList()) {
+		t.Error("list holds duplicate values")
+	}
+}
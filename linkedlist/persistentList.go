@@ -0,0 +1,214 @@
+package linkedlist
+
+import (
+	"iter"
+	"math/rand/v2"
+	"sync/atomic"
+)
+
+// PersistentList is a list of T stored in an immutable treap. A mutation
+// copies the O(log n) nodes on its path and publishes the new root with a
+// compare-and-swap, so versions share every node they have in common.
+//
+// Snapshot takes the current root in O(1) and never blocks or is blocked
+// by writers. Concurrent writers retry when another one published first,
+// which makes the list safe for concurrent use without a lock.
+type PersistentList[T any] struct {
+	root  atomic.Pointer[treapNode[T]]
+	equal func(a, b T) bool
+}
+
+// NewPersistent returns an empty persistent list that compares values
+// with ==.
+func NewPersistent[T comparable]() *PersistentList[T] {
+	return NewPersistentFunc(func(a, b T) bool { return a == b })
+}
+
+// NewPersistentFunc returns an empty persistent list that compares values
+// with equal.
+func NewPersistentFunc[T any](equal func(a, b T) bool) *PersistentList[T] {
+	return &PersistentList[T]{equal: equal}
+}
+
+// ConcurrentSafe marks the list as safe without an external lock.
+func (l *PersistentList[T]) ConcurrentSafe() {}
+
+// Snapshot returns a list holding the current version. Changes to either
+// list are not seen by the other.
+func (l *PersistentList[T]) Snapshot() List[T] {
+	snapshot := &PersistentList[T]{equal: l.equal}
+	snapshot.root.Store(l.root.Load())
+	return snapshot
+}
+
+// update publishes the root that change builds from the current one,
+// retrying from the newer root whenever another writer got there first.
+// It stops without publishing when change returns false.
+func (l *PersistentList[T]) update(change func(root *treapNode[T]) (*treapNode[T], bool)) bool {
+	for {
+		root := l.root.Load()
+		next, ok := change(root)
+		if !ok {
+			return false
+		}
+		if l.root.CompareAndSwap(root, next) {
+			return true
+		}
+	}
+}
+
+func (l *PersistentList[T]) Len() uint {
+	return l.root.Load().sizeOf()
+}
+
+func (l *PersistentList[T]) Find(val T) (index uint, found bool) {
+	for i, v := range l.All() {
+		if l.equal(v, val) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (l *PersistentList[T]) Get(index uint) (T, bool) {
+	node := nodeAtTreap(l.root.Load(), index)
+	if node == nil {
+		var zero T
+		return zero, false
+	}
+	return node.value, true
+}
+
+func (l *PersistentList[T]) Insert(index uint, val T) bool {
+	node := &treapNode[T]{value: val, priority: rand.Uint64(), size: 1}
+	return l.update(func(root *treapNode[T]) (*treapNode[T], bool) {
+		if index > root.sizeOf() {
+			return nil, false
+		}
+		left, right := splitPersistent(root, index)
+		return mergePersistent(mergePersistent(left, node), right), true
+	})
+}
+
+func (l *PersistentList[T]) Remove(index uint) bool {
+	return l.RemoveRange(index, index+1)
+}
+
+// InsertAll inserts values so that the first one ends up at index. The
+// whole batch is published at once.
+func (l *PersistentList[T]) InsertAll(index uint, values []T) bool {
+	var batch *treapNode[T]
+	for _, val := range values {
+		batch = mergeTreap(batch, &treapNode[T]{value: val, priority: rand.Uint64(), size: 1})
+	}
+
+	return l.update(func(root *treapNode[T]) (*treapNode[T], bool) {
+		if index > root.sizeOf() {
+			return nil, false
+		}
+		left, right := splitPersistent(root, index)
+		return mergePersistent(mergePersistent(left, batch), right), true
+	})
+}
+
+// Append inserts values at the end of the list.
+func (l *PersistentList[T]) Append(values ...T) {
+	var batch *treapNode[T]
+	for _, val := range values {
+		batch = mergeTreap(batch, &treapNode[T]{value: val, priority: rand.Uint64(), size: 1})
+	}
+
+	l.update(func(root *treapNode[T]) (*treapNode[T], bool) {
+		return mergePersistent(root, batch), true
+	})
+}
+
+// RemoveRange removes the elements in [from, to).
+func (l *PersistentList[T]) RemoveRange(from, to uint) bool {
+	return l.update(func(root *treapNode[T]) (*treapNode[T], bool) {
+		if from > to || to > root.sizeOf() {
+			return nil, false
+		}
+		left, rest := splitPersistent(root, from)
+		_, right := splitPersistent(rest, to-from)
+		return mergePersistent(left, right), true
+	})
+}
+
+// Slice returns a copy of the values in [from, to).
+func (l *PersistentList[T]) Slice(from, to uint) ([]T, bool) {
+	root := l.root.Load()
+	if from > to || to > root.sizeOf() {
+		return nil, false
+	}
+
+	_, rest := splitPersistent(root, from)
+	middle, _ := splitPersistent(rest, to-from)
+	values := make([]T, 0, to-from)
+	for _, v := range allTreap(middle) {
+		values = append(values, v)
+	}
+	return values, true
+}
+
+// splitPersistent is splitTreap without modifying node: it copies the
+// nodes on the path it cuts and shares the rest.
+func splitPersistent[T any](node *treapNode[T], k uint) (*treapNode[T], *treapNode[T]) {
+	if k >= node.sizeOf() {
+		return node, nil
+	}
+	if k == 0 {
+		return nil, node
+	}
+
+	c := *node
+	left := node.left.sizeOf()
+	if k <= left {
+		a, b := splitPersistent(node.left, k)
+		c.left = b
+		c.update()
+		return a, &c
+	}
+
+	a, b := splitPersistent(node.right, k-left-1)
+	c.right = a
+	c.update()
+	return &c, b
+}
+
+// mergePersistent is mergeTreap without modifying a or b.
+func mergePersistent[T any](a, b *treapNode[T]) *treapNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	if a.priority > b.priority {
+		c := *a
+		c.right = mergePersistent(a.right, b)
+		c.update()
+		return &c
+	}
+	c := *b
+	c.left = mergePersistent(a, b.left)
+	c.update()
+	return &c
+}
+
+func (l *PersistentList[T]) HandleList() []T {
+	var values []T
+	for _, v := range l.All() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// All yields every index and value of the version current when it starts.
+// It is safe to call concurrently with modifications.
+func (l *PersistentList[T]) All() iter.Seq2[uint, T] {
+	return func(yield func(uint, T) bool) {
+		allTreap(l.root.Load())(yield)
+	}
+}
@@ -0,0 +1,115 @@
+package linkedlist
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"testing/quick"
+)
+
+// TestPersistentSnapshotsQuick keeps a snapshot of every version and checks
+// that later changes leave all of them untouched.
+func TestPersistentSnapshotsQuick(t *testing.T) {
+	err := quick.Check(func(ops []uint16) bool {
+		l := NewPersistent[int]()
+		var model []int
+		var snapshots []List[int]
+		var models [][]int
+
+		for k, op := range ops {
+			switch {
+			case op%4 == 0 && len(model) > 0:
+				index := uint(op) % uint(len(model))
+				if !l.Remove(index) {
+					return false
+				}
+				model = slices.Delete(model, int(index), int(index)+1)
+			case op%4 == 1:
+				index := uint(op) % uint(len(model)+1)
+				if !l.InsertAll(index, []int{k, -k}) {
+					return false
+				}
+				model = slices.Insert(model, int(index), k, -k)
+			default:
+				index := uint(op) % uint(len(model)+1)
+				if !l.Insert(index, k) {
+					return false
+				}
+				model = slices.Insert(model, int(index), k)
+			}
+
+			if l.Len() != uint(len(model)) || !checkTreap(l.root.Load()) {
+				return false
+			}
+			snapshots = append(snapshots, l.Snapshot())
+			models = append(models, slices.Clone(model))
+		}
+
+		for k, snapshot := range snapshots {
+			if !slices.Equal(snapshot.HandleList(), models[k]) {
+				return false
+			}
+		}
+		for k, v := range model {
+			if out, ok := l.Get(uint(k)); !ok || out != v {
+				return false
+			}
+		}
+		if values, ok := l.Slice(0, uint(len(model))); !ok || !slices.Equal(values, model) {
+			return false
+		}
+		return !l.Insert(uint(len(model))+1, 0) && !l.Remove(uint(len(model)))
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestPersistentConcurrentSnapshots appends and removes pairs of values
+// while readers check that every snapshot they take holds whole pairs.
+// Run it with -race.
+func TestPersistentConcurrentSnapshots(t *testing.T) {
+	const (
+		workers = 4
+		rounds  = 300
+	)
+
+	l := NewPersistent[int]()
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := range rounds {
+				v := w*rounds + i
+				l.Append(v, -v)
+				if i%3 == 2 {
+					l.RemoveRange(0, 2)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range rounds {
+				snapshot := l.Snapshot()
+				values := snapshot.HandleList()
+				if uint(len(values)) != snapshot.Len() || len(values)%2 != 0 {
+					t.Errorf("snapshot of length %d holds %d values", snapshot.Len(), len(values))
+					return
+				}
+				for k := 0; k < len(values); k += 2 {
+					if values[k] != -values[k+1] {
+						t.Errorf("snapshot split a pair at %d: %v", k, values[k:k+2])
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if want := uint(workers * (rounds - rounds/3) * 2); l.Len() != want {
+		t.Errorf("expected length %d, got %d", want, l.Len())
+	}
+}
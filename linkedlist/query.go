@@ -0,0 +1,132 @@
+package linkedlist
+
+import "context"
+
+// FindFunc returns the index of the first element for which pred is true.
+func (l *LinkedList[T]) FindFunc(pred func(T) bool) (index uint, found bool) {
+	for i, v := range l.All() {
+		if pred(v) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// FindAllFunc returns the index of every element for which pred is true.
+func (l *LinkedList[T]) FindAllFunc(pred func(T) bool) []uint {
+	var indices []uint
+	for i, v := range l.All() {
+		if pred(v) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// Filter returns a new list of the elements for which pred is true. It has
+// the options and sorted mode of l.
+func (l *LinkedList[T]) Filter(pred func(T) bool) *LinkedList[T] {
+	filtered := l.emptyCopy()
+	var tail *Node[T]
+	for current := l.head; current != nil; current = current.Next {
+		if !pred(current.Value) {
+			continue
+		}
+		node := &Node[T]{Value: current.Value}
+		if tail == nil {
+			filtered.head = node
+		} else {
+			tail.Next = node
+		}
+		tail = node
+		filtered.length++
+	}
+	filtered.relinked()
+	return filtered
+}
+
+// Map returns a new list of fn applied to every element. It has the
+// options of l but is not in sorted mode.
+func (l *LinkedList[T]) Map(fn func(T) T) *LinkedList[T] {
+	mapped := l.emptyCopy()
+	mapped.sorted = false
+	var tail *Node[T]
+	for current := l.head; current != nil; current = current.Next {
+		node := &Node[T]{Value: fn(current.Value)}
+		if tail == nil {
+			mapped.head = node
+		} else {
+			tail.Next = node
+		}
+		tail = node
+	}
+	mapped.length = l.length
+	mapped.relinked()
+	return mapped
+}
+
+// Reduce folds the list from the head, starting from init.
+func (l *LinkedList[T]) Reduce(fn func(acc, v T) T, init T) T {
+	acc := init
+	for current := l.head; current != nil; current = current.Next {
+		acc = fn(acc, current.Value)
+	}
+	return acc
+}
+
+// RemoveIf removes every element for which pred is true in one pass and
+// returns how many it removed. The segment cache is refreshed once, from
+// the first removed position.
+func (l *LinkedList[T]) RemoveIf(pred func(T) bool) uint {
+	dummy := &Node[T]{Next: l.head}
+	prev := dummy
+	// anchor is the last node kept before the first removed one.
+	var anchor *Node[T]
+	var first, kept, removed uint
+
+	for current := l.head; current != nil; current = current.Next {
+		if !pred(current.Value) {
+			prev = current
+			kept++
+			continue
+		}
+		if anchor == nil {
+			anchor = prev
+			first = kept
+		}
+		l.indexRemoved(current)
+		l.filter.remove(current.Value)
+		prev.Next = current.Next
+		removed++
+	}
+	if removed == 0 {
+		return 0
+	}
+
+	l.head = dummy.Next
+	l.length -= removed
+	if !l.resizeSegments() {
+		l.refreshCacheFrom(first, anchor.Next)
+	}
+	return removed
+}
+
+// FindFuncConcurrently is FindFunc scanning the segments in parallel. It
+// still returns the first match. It reports false if ctx is done before the
+// search completes.
+func (l *LinkedList[T]) FindFuncConcurrently(ctx context.Context, pred func(T) bool) (index uint, found bool) {
+	return l.scan().first(ctx, pred)
+}
+
+// FindAllFuncConcurrently is FindAllFunc scanning the segments in parallel.
+// It reports false if ctx is done before the search completes.
+func (l *LinkedList[T]) FindAllFuncConcurrently(ctx context.Context, pred func(T) bool) ([]uint, bool) {
+	return l.scan().all(ctx, pred)
+}
+
+// CountFuncConcurrently returns the number of elements for which pred is
+// true, scanning the segments in parallel. It reports false if ctx is done
+// before the count completes.
+func (l *LinkedList[T]) CountFuncConcurrently(ctx context.Context, pred func(T) bool) (uint, bool) {
+	return l.scan().count(ctx, pred)
+}
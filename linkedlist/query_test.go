@@ -0,0 +1,89 @@
+package linkedlist
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+func TestQueriesQuick(t *testing.T) {
+	err := quick.Check(func(values []int8, div uint8) bool {
+		d := int(div%7) + 2
+		pred := func(v int) bool { return v%d == 0 }
+
+		l := New[int](WithSegmentSize(4), WithValueIndex(), WithBloomFilter(64, 0.01))
+		var model []int
+		for _, v := range values {
+			model = append(model, int(v))
+		}
+		l.Append(model...)
+
+		var want []uint
+		var kept, doubled []int
+		sum := 0
+		for i, v := range model {
+			if pred(v) {
+				want = append(want, uint(i))
+			} else {
+				kept = append(kept, v)
+			}
+			doubled = append(doubled, 2*v)
+			sum += v
+		}
+
+		index, found := l.FindFunc(pred)
+		if found != (len(want) > 0) || (found && index != want[0]) {
+			return false
+		}
+		cindex, cfound := l.FindFuncConcurrently(context.Background(), pred)
+		if cfound != found || cindex != index {
+			return false
+		}
+		if !slices.Equal(l.FindAllFunc(pred), want) {
+			return false
+		}
+		if all, ok := l.FindAllFuncConcurrently(context.Background(), pred); !ok || !slices.Equal(all, want) {
+			return false
+		}
+
+		filtered := l.Filter(func(v int) bool { return !pred(v) })
+		if !slices.Equal(filtered.HandleList(), kept) || !checkSegments(filtered, kept) {
+			return false
+		}
+		if !slices.Equal(l.Map(func(v int) int { return 2 * v }).HandleList(), doubled) {
+			return false
+		}
+		if l.Reduce(func(acc, v int) int { return acc + v }, 0) != sum {
+			return false
+		}
+
+		if l.RemoveIf(pred) != uint(len(want)) || !slices.Equal(l.HandleList(), kept) || !checkSegments(l, kept) || !checkLabels(l) {
+			return false
+		}
+		for i, v := range kept {
+			if index, found := l.Find(v); !found || index != uint(slices.Index(kept, v)) || !l.MayContain(kept[i]) {
+				return false
+			}
+		}
+		return l.Count(0) == 0
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueriesConcurrentlyCanceled(t *testing.T) {
+	l := New[int]()
+	l.Append(1, 2, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, found := l.FindFuncConcurrently(ctx, func(int) bool { return true }); found {
+		t.Error("FindFuncConcurrently found a value after cancellation")
+	}
+	if _, ok := l.FindAllFuncConcurrently(ctx, func(int) bool { return true }); ok {
+		t.Error("FindAllFuncConcurrently completed after cancellation")
+	}
+}
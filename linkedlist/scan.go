@@ -0,0 +1,193 @@
+package linkedlist
+
+import (
+	"context"
+	"iter"
+	"math"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// WithScanWorkers bounds the number of goroutines that scan the segments of
+// a list in SearchConcurrently and the other concurrent queries. It
+// defaults to GOMAXPROCS.
+func WithScanWorkers(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.workers = n
+		}
+	}
+}
+
+// checkInterval is the number of elements a traversal visits between two
+// looks at its context, and a scan worker between two checks for an
+// earlier match.
+const checkInterval = 64
+
+// segmentScan scans the cached segments of a list on a pool of workers.
+// segment yields the index and value of every element of segment i.
+type segmentScan[T any] struct {
+	workers  int
+	segments int
+	segment  func(i int) iter.Seq2[uint, T]
+}
+
+func newSegmentScan[T any](workers, segments int, segment func(i int) iter.Seq2[uint, T]) segmentScan[T] {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return segmentScan[T]{workers: workers, segments: segments, segment: segment}
+}
+
+// run calls visit for the elements of every segment. Workers take the
+// segments in list order, and visit returns true to stop the scan: the
+// segments after the lowest one that stopped are abandoned, while those
+// before it still run to the end, so the first match in the list is found
+// no matter how the workers are scheduled. visit runs concurrently for
+// different segments. run returns ctx.Err() if ctx ended before the scan
+// did.
+func (s segmentScan[T]) run(ctx context.Context, visit func(segment int, index uint, v T) bool) error {
+	var next atomic.Int64
+	var stop atomic.Int64
+	stop.Store(math.MaxInt64)
+	var canceled atomic.Bool
+	done := ctx.Done()
+
+	var wg sync.WaitGroup
+	for range min(s.workers, s.segments) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := next.Add(1) - 1
+				if i >= int64(s.segments) || i > stop.Load() {
+					return
+				}
+
+				visited := 0
+				for index, v := range s.segment(int(i)) {
+					if visited++; visited%checkInterval == 1 {
+						select {
+						case <-done:
+							canceled.Store(true)
+							return
+						default:
+						}
+						if i > stop.Load() {
+							return
+						}
+					}
+
+					if visit(int(i), index, v) {
+						storeMin(&stop, i)
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if canceled.Load() {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// storeMin lowers x to v unless it is already lower.
+func storeMin(x *atomic.Int64, v int64) {
+	for {
+		old := x.Load()
+		if v >= old || x.CompareAndSwap(old, v) {
+			return
+		}
+	}
+}
+
+// first returns the lowest index whose value pred accepts.
+func (s segmentScan[T]) first(ctx context.Context, pred func(T) bool) (uint, bool) {
+	var best atomic.Int64
+	best.Store(math.MaxInt64)
+	err := s.run(ctx, func(_ int, index uint, v T) bool {
+		if pred(v) {
+			storeMin(&best, int64(index))
+			return true
+		}
+		return false
+	})
+
+	if err != nil || best.Load() == math.MaxInt64 {
+		return 0, false
+	}
+	return uint(best.Load()), true
+}
+
+// all returns every index whose value pred accepts, in order.
+func (s segmentScan[T]) all(ctx context.Context, pred func(T) bool) ([]uint, bool) {
+	hits := make([][]uint, s.segments)
+	err := s.run(ctx, func(segment int, index uint, v T) bool {
+		if pred(v) {
+			hits[segment] = append(hits[segment], index)
+		}
+		return false
+	})
+
+	if err != nil {
+		return nil, false
+	}
+	return slices.Concat(hits...), true
+}
+
+// count returns the number of values pred accepts.
+func (s segmentScan[T]) count(ctx context.Context, pred func(T) bool) (uint, bool) {
+	counts := make([]uint, s.segments)
+	err := s.run(ctx, func(segment int, _ uint, v T) bool {
+		if pred(v) {
+			counts[segment]++
+		}
+		return false
+	})
+
+	if err != nil {
+		return 0, false
+	}
+	var total uint
+	for _, c := range counts {
+		total += c
+	}
+	return total, true
+}
+
+// scan returns a scan over the cached segments of l.
+func (l *LinkedList[T]) scan() segmentScan[T] {
+	return newSegmentScan(l.workers, len(l.nodes), func(i int) iter.Seq2[uint, T] {
+		return func(yield func(uint, T) bool) {
+			index, end := l.starts[i], l.segmentEnd(i)
+			for current := l.nodes[i]; index < end; current, index = current.Next, index+1 {
+				if !yield(index, current.Value) {
+					return
+				}
+			}
+		}
+	})
+}
+
+// scan returns a scan over the lead nodes and the cached segments of l.
+func (l *DoublyLinkedList[T]) scan() segmentScan[T] {
+	return newSegmentScan(l.workers, len(l.nodes)+1, func(i int) iter.Seq2[uint, T] {
+		return func(yield func(uint, T) bool) {
+			index, end, current := uint(0), min(l.lead, l.length), l.head
+			if i > 0 {
+				index, current = l.start(i-1), l.nodes[i-1]
+				end = min(index+l.part, l.length)
+			}
+			for ; index < end; current, index = current.Next, index+1 {
+				if !yield(index, current.Value) {
+					return
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package linkedlist
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"testing/quick"
+	"time"
+)
+
+func TestScanFirstMatchQuick(t *testing.T) {
+	err := quick.Check(func(values []uint8, find uint8, workers uint8) bool {
+		find %= 8
+		l := New[int](WithSegmentSize(3), WithScanWorkers(int(workers%5)+1))
+		d := NewDoubly[int](WithSegmentSize(3), WithScanWorkers(int(workers%5)+1))
+		var model []int
+		for _, v := range values {
+			model = append(model, int(v%8))
+		}
+		l.Append(model...)
+		d.Append(model...)
+
+		want := slices.Index(model, int(find))
+		pred := func(v int) bool { return v == int(find) }
+		for _, search := range []func(context.Context, int) (int, bool){l.SearchConcurrently, d.SearchConcurrently} {
+			if index, found := search(context.Background(), int(find)); found != (want >= 0) || (found && index != want) {
+				return false
+			}
+		}
+
+		count, ok := l.CountFuncConcurrently(context.Background(), pred)
+		return ok && count == l.Count(int(find))
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanBoundsWorkers(t *testing.T) {
+	const workers = 3
+	l := New[int](WithSegmentSize(10), WithScanWorkers(workers))
+	l.Append(make([]int, 1000)...)
+
+	var running, peak atomic.Int64
+	segments := make([]atomic.Int64, l.SegmentCount())
+	err := l.scan().run(context.Background(), func(segment int, index uint, v int) bool {
+		if index%l.part == 0 {
+			storeMax(&peak, running.Add(1))
+			time.Sleep(time.Millisecond)
+			running.Add(-1)
+		}
+		segments[segment].Add(1)
+		return false
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if peak.Load() > workers {
+		t.Errorf("expected at most %d workers, got %d", workers, peak.Load())
+	}
+	for i := range segments {
+		if segments[i].Load() != 10 {
+			t.Fatalf("segment %d visited %d values, expected 10", i, segments[i].Load())
+		}
+	}
+}
+
+func storeMax(x *atomic.Int64, v int64) {
+	for {
+		old := x.Load()
+		if v <= old || x.CompareAndSwap(old, v) {
+			return
+		}
+	}
+}
+
+func TestScanHonorsDeadline(t *testing.T) {
+	l := New[int](WithSegmentSize(10), WithScanWorkers(2))
+	l.Append(make([]int, 1000)...)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, found := l.FindFuncConcurrently(ctx, func(int) bool {
+		time.Sleep(time.Millisecond)
+		return false
+	})
+	if found {
+		t.Error("found a value the predicate rejects")
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Errorf("scan ran %v past a 5ms deadline", elapsed)
+	}
+	if _, ok := l.CountFuncConcurrently(ctx, func(int) bool { return true }); ok {
+		t.Error("CountFuncConcurrently completed after the deadline")
+	}
+}
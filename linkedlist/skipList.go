@@ -0,0 +1,190 @@
+package linkedlist
+
+import (
+	"iter"
+	"math/rand/v2"
+)
+
+const (
+	skipListMaxLevel = 32
+	// skipListP is the chance that a node is promoted one more level.
+	skipListP = 0.25
+)
+
+type skipNode[T any] struct {
+	value T
+	next  []skipLink[T]
+}
+
+// skipLink points at the next node on one level. span is the number of
+// positions it skips; a link with no node spans to the end of the list.
+type skipLink[T any] struct {
+	node *skipNode[T]
+	span uint
+}
+
+// SkipList is an indexable skip list of T. Every link counts the positions
+// it skips, so Get, Insert and Remove by index take O(log n) expected time
+// instead of walking the list.
+type SkipList[T any] struct {
+	head   *skipNode[T]
+	level  int
+	length uint
+	equal  func(a, b T) bool
+	rand   *rand.Rand
+}
+
+// NewSkipList returns an empty skip list that compares values with ==.
+func NewSkipList[T comparable]() *SkipList[T] {
+	return NewSkipListFunc(func(a, b T) bool { return a == b })
+}
+
+// NewSkipListFunc returns an empty skip list that compares values with equal.
+func NewSkipListFunc[T any](equal func(a, b T) bool) *SkipList[T] {
+	return &SkipList[T]{
+		head:  &skipNode[T]{next: make([]skipLink[T], skipListMaxLevel)},
+		level: 1,
+		equal: equal,
+		rand:  rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+}
+
+func (l *SkipList[T]) Len() uint {
+	return l.length
+}
+
+func (l *SkipList[T]) Find(val T) (index uint, found bool) {
+	index = 0
+	for current := l.head.next[0].node; current != nil; current = current.next[0].node {
+		if l.equal(current.value, val) {
+			return index, true
+		}
+		index++
+	}
+	return 0, false
+}
+
+func (l *SkipList[T]) Get(index uint) (T, bool) {
+	if index >= l.length {
+		var zero T
+		return zero, false
+	}
+
+	// Positions are counted from 1 so the head sits at 0.
+	rank := index + 1
+	current, traversed := l.head, uint(0)
+	for i := l.level - 1; i >= 0; i-- {
+		for current.next[i].node != nil && traversed+current.next[i].span <= rank {
+			traversed += current.next[i].span
+			current = current.next[i].node
+		}
+		if traversed == rank {
+			break
+		}
+	}
+	return current.value, true
+}
+
+func (l *SkipList[T]) Insert(index uint, val T) bool {
+	if index > l.length {
+		return false
+	}
+
+	var update [skipListMaxLevel]*skipNode[T]
+	var rank [skipListMaxLevel]uint
+	l.predecessors(index, &update, &rank)
+
+	level := l.randomLevel()
+	if level > l.level {
+		for i := l.level; i < level; i++ {
+			update[i] = l.head
+			rank[i] = 0
+			l.head.next[i].span = l.length
+		}
+		l.level = level
+	}
+
+	node := &skipNode[T]{value: val, next: make([]skipLink[T], level)}
+	for i := 0; i < level; i++ {
+		node.next[i].node = update[i].next[i].node
+		node.next[i].span = update[i].next[i].span - (index - rank[i])
+		update[i].next[i].node = node
+		update[i].next[i].span = index - rank[i] + 1
+	}
+	for i := level; i < l.level; i++ {
+		update[i].next[i].span++
+	}
+
+	l.length++
+	return true
+}
+
+func (l *SkipList[T]) Remove(index uint) bool {
+	if index >= l.length {
+		return false
+	}
+
+	var update [skipListMaxLevel]*skipNode[T]
+	var rank [skipListMaxLevel]uint
+	l.predecessors(index, &update, &rank)
+
+	node := update[0].next[0].node
+	for i := 0; i < l.level; i++ {
+		if update[i].next[i].node == node {
+			update[i].next[i].span += node.next[i].span - 1
+			update[i].next[i].node = node.next[i].node
+		} else {
+			update[i].next[i].span--
+		}
+	}
+	for l.level > 1 && l.head.next[l.level-1].node == nil {
+		l.level--
+	}
+
+	l.length--
+	return true
+}
+
+// predecessors fills update with the last node before index on every level
+// and rank with the position of that node.
+func (l *SkipList[T]) predecessors(index uint, update *[skipListMaxLevel]*skipNode[T], rank *[skipListMaxLevel]uint) {
+	current, traversed := l.head, uint(0)
+	for i := l.level - 1; i >= 0; i-- {
+		for current.next[i].node != nil && traversed+current.next[i].span <= index {
+			traversed += current.next[i].span
+			current = current.next[i].node
+		}
+		update[i] = current
+		rank[i] = traversed
+	}
+}
+
+func (l *SkipList[T]) randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && l.rand.Float64() < skipListP {
+		level++
+	}
+	return level
+}
+
+func (l *SkipList[T]) HandleList() []T {
+	var values []T
+	for current := l.head.next[0].node; current != nil; current = current.next[0].node {
+		values = append(values, current.value)
+	}
+	return values
+}
+
+// All yields every index and value in list order. Like the LinkedList
+// iterators it must not run concurrently with modifications.
+func (l *SkipList[T]) All() iter.Seq2[uint, T] {
+	return func(yield func(uint, T) bool) {
+		index := uint(0)
+		for current := l.head.next[0].node; current != nil; current = current.next[0].node {
+			if !yield(index, current.value) {
+				return
+			}
+			index++
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package linkedlist
+
+import (
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+// checkSpans reports whether every link of l skips exactly the positions
+// between its two nodes.
+func checkSpans(l *SkipList[int]) bool {
+	positions := map[*skipNode[int]]uint{l.head: 0}
+	rank := uint(0)
+	for current := l.head.next[0].node; current != nil; current = current.next[0].node {
+		rank++
+		positions[current] = rank
+	}
+
+	for i := 0; i < l.level; i++ {
+		for current := l.head; current != nil; current = current.next[i].node {
+			end := l.length + 1
+			if next := current.next[i].node; next != nil {
+				end = positions[next]
+			} else if current.next[i].span != l.length-positions[current] {
+				return false
+			}
+			if next := current.next[i].node; next != nil && current.next[i].span != end-positions[current] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestSkipListPropertiesQuick(t *testing.T) {
+	err := quick.Check(func(ops []uint16) bool {
+		l := NewSkipList[int]()
+		var model []int
+
+		for k, op := range ops {
+			if op%3 == 0 && len(model) > 0 {
+				index := uint(op) % uint(len(model))
+				if !l.Remove(index) {
+					return false
+				}
+				model = slices.Delete(model, int(index), int(index)+1)
+			} else {
+				index := uint(op) % uint(len(model)+1)
+				if !l.Insert(index, k) {
+					return false
+				}
+				model = slices.Insert(model, int(index), k)
+			}
+
+			if l.Len() != uint(len(model)) || !checkSpans(l) {
+				return false
+			}
+		}
+
+		for k, v := range model {
+			if out, ok := l.Get(uint(k)); !ok || out != v {
+				return false
+			}
+			if index, found := l.Find(v); !found || index != uint(k) {
+				return false
+			}
+		}
+		if _, ok := l.Get(uint(len(model))); ok {
+			return false
+		}
+		return slices.Equal(l.HandleList(), model) && !l.Insert(uint(len(model))+1, 0) && !l.Remove(uint(len(model)))
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,146 @@
+package linkedlist
+
+import "sort"
+
+// Sort orders the list by less with a stable bottom-up merge sort. It
+// relinks the nodes in place, so it takes O(n log n) time and O(1) extra
+// memory. The list remembers less and stays in sorted mode until an insert
+// breaks the order.
+func (l *LinkedList[T]) Sort(less func(a, b T) bool) {
+	l.head = mergeSort(l.head, l.length, less)
+	l.less = less
+	l.sorted = true
+	l.rebuildCache()
+	l.reindex()
+}
+
+// Sorted reports whether the list is known to be ordered by the less
+// function of the last Sort.
+func (l *LinkedList[T]) Sorted() bool {
+	return l.sorted
+}
+
+// InsertSorted inserts val after every element that does not order after
+// it and returns its index. It fails unless the list is in sorted mode,
+// and leaves a list whose order was broken since the last Sort as it is,
+// or if the list is full.
+func (l *LinkedList[T]) InsertSorted(val T) (uint, bool) {
+	if !l.sorted || !l.fits(1) {
+		return 0, false
+	}
+
+	// The first segment starting after val bounds the search.
+	segment := sort.Search(len(l.nodes), func(i int) bool {
+		return l.less(val, l.nodes[i].Value)
+	})
+
+	index := uint(0)
+	if segment > 0 {
+		index = l.starts[segment-1]
+		for current := l.nodes[segment-1]; current != nil && !l.less(val, current.Value); current = current.Next {
+			index++
+		}
+	}
+
+	l.Insert(index, val)
+	return index, true
+}
+
+// FindSorted returns the index of the first element equivalent to val under
+// the less function of the last Sort. In sorted mode it binary searches the
+// cached segment starts and walks a single segment; otherwise it falls back
+// to Find.
+func (l *LinkedList[T]) FindSorted(val T) (index uint, found bool) {
+	if !l.sorted {
+		return l.Find(val)
+	}
+
+	// The first segment starting at or after val bounds the search.
+	segment := sort.Search(len(l.nodes), func(i int) bool {
+		return !l.less(l.nodes[i].Value, val)
+	})
+	if segment > 0 {
+		segment--
+	}
+	if segment == len(l.nodes) {
+		return 0, false
+	}
+
+	index = l.starts[segment]
+	for current := l.nodes[segment]; current != nil; current = current.Next {
+		if !l.less(current.Value, val) {
+			return index, !l.less(val, current.Value)
+		}
+		index++
+	}
+	return 0, false
+}
+
+// checkOrder leaves sorted mode if the nodes from first up to and
+// including the node after last are out of order.
+func (l *LinkedList[T]) checkOrder(first, last *Node[T]) {
+	if !l.sorted {
+		return
+	}
+	for current := first; current != last.Next && current.Next != nil; current = current.Next {
+		if l.less(current.Next.Value, current.Value) {
+			l.sorted = false
+			return
+		}
+	}
+}
+
+// mergeSort sorts the n nodes starting at head by merging runs of doubling
+// width and returns the new head.
+func mergeSort[T any](head *Node[T], n uint, less func(a, b T) bool) *Node[T] {
+	dummy := &Node[T]{Next: head}
+	for width := uint(1); width < n; width *= 2 {
+		tail := dummy
+		current := dummy.Next
+		for current != nil {
+			left := current
+			right := cut(left, width)
+			current = cut(right, width)
+			tail = merge(tail, left, right, less)
+		}
+	}
+	return dummy.Next
+}
+
+// cut detaches the first n nodes starting at head and returns the rest.
+func cut[T any](head *Node[T], n uint) *Node[T] {
+	for ; head != nil && n > 1; n-- {
+		head = head.Next
+	}
+	if head == nil {
+		return nil
+	}
+	rest := head.Next
+	head.Next = nil
+	return rest
+}
+
+// merge links the merge of left and right after tail and returns the last
+// merged node. Ties take from left, which keeps the sort stable.
+func merge[T any](tail, left, right *Node[T], less func(a, b T) bool) *Node[T] {
+	for left != nil && right != nil {
+		if less(right.Value, left.Value) {
+			tail.Next = right
+			right = right.Next
+		} else {
+			tail.Next = left
+			left = left.Next
+		}
+		tail = tail.Next
+	}
+
+	if left != nil {
+		tail.Next = left
+	} else {
+		tail.Next = right
+	}
+	for tail.Next != nil {
+		tail = tail.Next
+	}
+	return tail
+}
@@ -0,0 +1,116 @@
+package linkedlist
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+type keyed struct {
+	key, seq int
+}
+
+func lessKey(a, b keyed) bool {
+	return a.key < b.key
+}
+
+func TestLinkedListSortQuick(t *testing.T) {
+	err := quick.Check(func(keys []int8, part uint8) bool {
+		l := NewFunc(func(a, b keyed) bool { return a == b }, WithSegmentSize(uint(part%16)+1))
+		model := make([]keyed, len(keys))
+		for k, key := range keys {
+			model[k] = keyed{key: int(key % 8), seq: k}
+			l.Insert(uint(k), model[k])
+		}
+
+		l.Sort(lessKey)
+		slices.SortStableFunc(model, func(a, b keyed) int { return cmp.Compare(a.key, b.key) })
+
+		if !l.Sorted() || !slices.Equal(l.HandleList(), model) {
+			return false
+		}
+		for i, node := range l.nodes {
+			if node.Value != model[i*int(l.part)] {
+				return false
+			}
+		}
+		return true
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLinkedListInsertSortedQuick(t *testing.T) {
+	err := quick.Check(func(values []int8, finds []int8) bool {
+		l := New[int]()
+		if _, ok := l.InsertSorted(1); ok {
+			return false
+		}
+		l.Sort(cmp.Less[int])
+
+		var model []int
+		for _, v := range values {
+			index, ok := l.InsertSorted(int(v))
+			want, _ := slices.BinarySearch(model, int(v)+1)
+			if !ok || index != uint(want) {
+				return false
+			}
+			model = slices.Insert(model, want, int(v))
+		}
+
+		if !l.Sorted() || !slices.Equal(l.HandleList(), model) {
+			return false
+		}
+		for _, v := range append(finds, values...) {
+			index, found := l.FindSorted(int(v))
+			want, wantFound := slices.BinarySearch(model, int(v))
+			if found != wantFound || (found && index != uint(want)) {
+				return false
+			}
+		}
+		return true
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLinkedListSortedMode(t *testing.T) {
+	l := New[int]()
+	l.Append(3, 1, 2)
+	if l.Sorted() {
+		t.Error("Expected a new list not to be in sorted mode")
+	}
+
+	l.Sort(cmp.Less[int])
+	l.Insert(1, 1)
+	l.Append(3, 4)
+	if !l.Sorted() {
+		t.Errorf("Expected ordered inserts to keep sorted mode, got %v", l.HandleList())
+	}
+
+	l.Insert(0, 5)
+	if l.Sorted() {
+		t.Errorf("Expected an unordered insert to leave sorted mode, got %v", l.HandleList())
+	}
+	if index, found := l.FindSorted(5); !found || index != 0 {
+		t.Errorf("FindSorted(5): expected the unsorted fallback to find index 0, got %d, found %t", index, found)
+	}
+
+	if _, ok := l.InsertSorted(2); ok || l.Sorted() {
+		t.Errorf("InsertSorted(2): expected a list out of sorted mode to fail and stay as it is, got %v", l.HandleList())
+	}
+
+	l.Sort(cmp.Less[int])
+	index, ok := l.InsertSorted(2)
+	if !ok || index != 3 || !l.Sorted() {
+		t.Errorf("InsertSorted(2): expected index 3 in a sorted list, got %d, ok %t, list %v", index, ok, l.HandleList())
+	}
+	if values := l.HandleList(); !slices.Equal(values, []int{1, 1, 2, 2, 3, 3, 4, 5}) {
+		t.Errorf("Expected [1 1 2 2 3 3 4 5], got %v", values)
+	}
+}
@@ -0,0 +1,157 @@
+package linkedlist
+
+// The operations below move nodes between positions and lists by relinking
+// them; no value is copied. Lists that receive or lose nodes get their
+// segment cache rebuilt from the first position that moved.
+
+// Reverse reverses the order of the list.
+func (l *LinkedList[T]) Reverse() {
+	var prev *Node[T]
+	current := l.head
+	for current != nil {
+		next := current.Next
+		current.Next = prev
+		prev = current
+		current = next
+	}
+	l.head = prev
+
+	if l.length > 1 {
+		l.sorted = false
+	}
+	l.rebuildCache()
+	l.reindex()
+}
+
+// Rotate moves the first k elements to the end of the list, so the element
+// at index k becomes the head. A negative k rotates the other way.
+func (l *LinkedList[T]) Rotate(k int) {
+	if l.length < 2 {
+		return
+	}
+
+	n := int(l.length)
+	shift := uint((k%n + n) % n)
+	if shift == 0 {
+		return
+	}
+
+	newTail := l.seek(shift - 1)
+	oldTail := l.seek(l.length - 1)
+	oldTail.Next = l.head
+	l.head = newTail.Next
+	newTail.Next = nil
+
+	l.sorted = false
+	l.rebuildCache()
+	l.reindex()
+}
+
+// Split moves the elements before index into the first returned list and
+// the rest into the second, leaving l empty. Both lists keep the options
+// and sorted mode of l. It returns nil lists if index is out of range.
+func (l *LinkedList[T]) Split(index uint) (*LinkedList[T], *LinkedList[T]) {
+	if index > l.length {
+		return nil, nil
+	}
+
+	front, back := l.emptyCopy(), l.emptyCopy()
+	if index == 0 {
+		back.head = l.head
+	} else {
+		last := l.seek(index - 1)
+		front.head = l.head
+		back.head = last.Next
+		last.Next = nil
+	}
+	front.length = index
+	back.length = l.length - index
+	l.clear()
+
+	front.relinked()
+	back.relinked()
+	return front, back
+}
+
+// Concat moves every element of other to the end of l, leaving other empty.
+// It fails if they would exceed the capacity of l.
+func (l *LinkedList[T]) Concat(other *LinkedList[T]) bool {
+	return l.Splice(l.length, other)
+}
+
+// Splice moves every element of other into l so that the first one ends
+// up at index, leaving other empty. It fails if they would exceed the
+// capacity of l. Like InsertAll, it keeps sorted mode if the moved run
+// fits the order, and labels only the moved nodes for the value index.
+func (l *LinkedList[T]) Splice(index uint, other *LinkedList[T]) bool {
+	if index > l.length || other == l || !l.fits(other.length) {
+		return false
+	}
+	if other.length == 0 {
+		return true
+	}
+
+	first, count := other.head, other.length
+	last := other.seek(count - 1)
+	var prev *Node[T]
+	if index == 0 {
+		last.Next = l.head
+		l.head = first
+		l.checkOrder(first, last)
+	} else {
+		prev = l.seek(index - 1)
+		last.Next = prev.Next
+		prev.Next = first
+		l.checkOrder(prev, last)
+	}
+	l.length += count
+	l.indexInserted(prev, first, count)
+	for current, i := first, uint(0); i < count; current, i = current.Next, i+1 {
+		l.filter.add(current.Value)
+	}
+	other.clear()
+
+	if !l.resizeSegments() {
+		l.refreshCacheFrom(index, first)
+	}
+	return true
+}
+
+// emptyCopy returns an empty list with the options and order of l.
+func (l *LinkedList[T]) emptyCopy() *LinkedList[T] {
+	c := &LinkedList[T]{
+		equal:     l.equal,
+		part:      l.part,
+		fixedPart: l.fixedPart,
+		workers:   l.workers,
+		capacity:  l.capacity,
+		less:      l.less,
+		sorted:    l.sorted,
+	}
+	if l.index != nil {
+		c.index = newValueIndex[T]()
+	}
+	c.filter = l.filter.empty()
+	return c
+}
+
+func (l *LinkedList[T]) clear() {
+	l.head = nil
+	l.length = 0
+	l.nodes = nil
+	l.starts = nil
+	if l.index != nil {
+		l.index = newValueIndex[T]()
+	}
+	l.filter.reset()
+}
+
+// relinked rebuilds the segment cache, value index and Bloom filter after
+// the nodes of l were replaced.
+func (l *LinkedList[T]) relinked() {
+	if !l.resizeSegments() {
+		l.rebuildCache()
+	}
+	l.reindex()
+	l.refilter()
+}
@@ -0,0 +1,114 @@
+package linkedlist
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+// checkSegments reports whether the segment cache of l matches model.
+func checkSegments(l *LinkedList[int], model []int) bool {
+	if l.length != uint(len(model)) || l.Validate() != nil {
+		return false
+	}
+	return slices.Equal(slices.Collect(l.Values()), model)
+}
+
+func TestLinkedListStructurePropertiesQuick(t *testing.T) {
+	err := quick.Check(func(ops []int16, values []int, part uint8) bool {
+		opts := []Option{WithSegmentSize(uint(part%16) + 1)}
+		if part%3 == 0 {
+			opts = nil
+		}
+		l := New[int](opts...)
+		l.Append(values...)
+		model := slices.Clone(values)
+
+		for k, op := range ops {
+			index := uint(op) % uint(len(model)+1)
+			batch := values[:k%(len(values)+1)]
+
+			switch uint16(op) % 5 {
+			case 0:
+				l.Reverse()
+				slices.Reverse(model)
+			case 1:
+				l.Rotate(int(op))
+				if n := len(model); n > 0 {
+					shift := (int(op)%n + n) % n
+					model = append(model[shift:], model[:shift]...)
+				}
+			case 2:
+				front, back := l.Split(index)
+				if l.length != 0 || !checkSegments(front, model[:index]) || !checkSegments(back, model[index:]) {
+					return false
+				}
+				if !front.Concat(back) || back.length != 0 || back.head != nil {
+					return false
+				}
+				l = front
+			case 3:
+				other := New[int](opts...)
+				other.Append(batch...)
+				if !l.Splice(index, other) || other.length != 0 {
+					return false
+				}
+				model = slices.Insert(model, int(index), batch...)
+			case 4:
+				other := New[int](opts...)
+				other.Append(batch...)
+				if !l.Concat(other) {
+					return false
+				}
+				model = append(model, batch...)
+			}
+
+			if !checkSegments(l, model) {
+				return false
+			}
+		}
+
+		front, back := l.Split(l.length + 1)
+		return front == nil && back == nil && !l.Splice(0, l)
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLinkedListSpliceSortedMode(t *testing.T) {
+	l := New[int](WithValueIndex())
+	l.Append(1, 2, 5, 6)
+	l.Sort(cmp.Less[int])
+
+	labels := make(map[*Node[int]]uint64)
+	for current := l.head; current != nil; current = current.Next {
+		labels[current] = current.label
+	}
+
+	other := New[int](WithValueIndex())
+	other.Append(3, 4, 4)
+	if !l.Splice(2, other) || !l.Sorted() {
+		t.Errorf("expected a splice in order to keep sorted mode, got %v", l.HandleList())
+	}
+	for node, label := range labels {
+		if node.label != label {
+			t.Errorf("Splice relabelled the node holding %d", node.Value)
+		}
+	}
+	if err := l.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if got := l.FindAll(4); !slices.Equal(got, []uint{3, 4}) {
+		t.Errorf("FindAll(4): expected [3 4], got %v", got)
+	}
+
+	other = New[int]()
+	other.Append(0)
+	l.Splice(3, other)
+	if l.Sorted() {
+		t.Errorf("expected a splice out of order to leave sorted mode, got %v", l.HandleList())
+	}
+}
@@ -0,0 +1,299 @@
+package linkedlist
+
+import (
+	"iter"
+	"math/rand/v2"
+	"slices"
+)
+
+type treapNode[T any] struct {
+	value    T
+	priority uint64
+	size     uint
+	left     *treapNode[T]
+	right    *treapNode[T]
+}
+
+func (n *treapNode[T]) sizeOf() uint {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *treapNode[T]) update() {
+	n.size = n.left.sizeOf() + n.right.sizeOf() + 1
+}
+
+// Treap is a list of T stored in a randomized balanced tree keyed by
+// position: a node's index is the size of everything to its left. Insert,
+// Remove and Get by index take O(log n) expected time. Once sorted, Rank
+// and Select answer order statistics in O(log n) as well.
+type Treap[T any] struct {
+	root  *treapNode[T]
+	equal func(a, b T) bool
+	rand  *rand.Rand
+
+	// less is the order of the last Sort, and sorted tells whether the
+	// list still follows it.
+	less   func(a, b T) bool
+	sorted bool
+}
+
+// NewTreap returns an empty treap that compares values with ==.
+func NewTreap[T comparable]() *Treap[T] {
+	return NewTreapFunc(func(a, b T) bool { return a == b })
+}
+
+// NewTreapFunc returns an empty treap that compares values with equal.
+func NewTreapFunc[T any](equal func(a, b T) bool) *Treap[T] {
+	return &Treap[T]{
+		equal: equal,
+		rand:  rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+}
+
+func (l *Treap[T]) Len() uint {
+	return l.root.sizeOf()
+}
+
+func (l *Treap[T]) Find(val T) (index uint, found bool) {
+	for i, v := range l.All() {
+		if l.equal(v, val) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (l *Treap[T]) Get(index uint) (T, bool) {
+	node := l.nodeAt(index)
+	if node == nil {
+		var zero T
+		return zero, false
+	}
+	return node.value, true
+}
+
+func (l *Treap[T]) nodeAt(index uint) *treapNode[T] {
+	return nodeAtTreap(l.root, index)
+}
+
+// nodeAtTreap returns the node at index in the tree, or nil.
+func nodeAtTreap[T any](node *treapNode[T], index uint) *treapNode[T] {
+	for node != nil {
+		left := node.left.sizeOf()
+		switch {
+		case index < left:
+			node = node.left
+		case index == left:
+			return node
+		default:
+			index -= left + 1
+			node = node.right
+		}
+	}
+	return nil
+}
+
+func (l *Treap[T]) Insert(index uint, val T) bool {
+	if index > l.Len() {
+		return false
+	}
+
+	if l.sorted {
+		if index > 0 && l.less(val, l.nodeAt(index-1).value) {
+			l.sorted = false
+		}
+		if next := l.nodeAt(index); next != nil && l.less(next.value, val) {
+			l.sorted = false
+		}
+	}
+
+	node := &treapNode[T]{value: val, priority: l.rand.Uint64(), size: 1}
+	left, right := splitTreap(l.root, index)
+	l.root = mergeTreap(mergeTreap(left, node), right)
+	return true
+}
+
+func (l *Treap[T]) Remove(index uint) bool {
+	if index >= l.Len() {
+		return false
+	}
+
+	left, rest := splitTreap(l.root, index)
+	_, right := splitTreap(rest, 1)
+	l.root = mergeTreap(left, right)
+	return true
+}
+
+// splitTreap returns a tree of the first k elements of node and a tree of
+// the rest.
+func splitTreap[T any](node *treapNode[T], k uint) (*treapNode[T], *treapNode[T]) {
+	if node == nil {
+		return nil, nil
+	}
+
+	left := node.left.sizeOf()
+	if k <= left {
+		a, b := splitTreap(node.left, k)
+		node.left = b
+		node.update()
+		return a, node
+	}
+
+	a, b := splitTreap(node.right, k-left-1)
+	node.right = a
+	node.update()
+	return node, b
+}
+
+// mergeTreap joins two trees, with every element of a before those of b.
+func mergeTreap[T any](a, b *treapNode[T]) *treapNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	if a.priority > b.priority {
+		a.right = mergeTreap(a.right, b)
+		a.update()
+		return a
+	}
+	b.left = mergeTreap(a, b.left)
+	b.update()
+	return b
+}
+
+// Sort orders the treap by less, keeping equal elements in their current
+// order, and puts it in sorted mode.
+func (l *Treap[T]) Sort(less func(a, b T) bool) {
+	values := l.HandleList()
+	slices.SortStableFunc(values, func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	l.root = nil
+	for _, val := range values {
+		l.root = mergeTreap(l.root, &treapNode[T]{value: val, priority: l.rand.Uint64(), size: 1})
+	}
+	l.less = less
+	l.sorted = true
+}
+
+// Sorted reports whether the treap is known to be ordered by the less
+// function of the last Sort.
+func (l *Treap[T]) Sorted() bool {
+	return l.sorted
+}
+
+// InsertSorted inserts val after every element that does not order after
+// it and returns its index. It fails unless the treap is in sorted mode,
+// and leaves a treap whose order was broken since the last Sort as it is.
+func (l *Treap[T]) InsertSorted(val T) (uint, bool) {
+	if !l.sorted {
+		return 0, false
+	}
+
+	index := uint(0)
+	for node := l.root; node != nil; {
+		if l.less(val, node.value) {
+			node = node.left
+		} else {
+			index += node.left.sizeOf() + 1
+			node = node.right
+		}
+	}
+
+	l.Insert(index, val)
+	return index, true
+}
+
+// FindSorted returns the index of the first element equivalent to val. In
+// sorted mode it descends the tree; otherwise it falls back to Find.
+func (l *Treap[T]) FindSorted(val T) (index uint, found bool) {
+	if !l.sorted {
+		return l.Find(val)
+	}
+
+	index, _ = l.Rank(val)
+	node := l.nodeAt(index)
+	if node == nil || l.less(val, node.value) {
+		return 0, false
+	}
+	return index, true
+}
+
+// Rank returns the number of elements ordered before val, which is the
+// index val would take. It fails unless the treap is in sorted mode.
+func (l *Treap[T]) Rank(val T) (uint, bool) {
+	if !l.sorted {
+		return 0, false
+	}
+
+	rank := uint(0)
+	for node := l.root; node != nil; {
+		if l.less(node.value, val) {
+			rank += node.left.sizeOf() + 1
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+	return rank, true
+}
+
+// Select returns the k-th smallest element, counting from 0. It fails
+// unless the treap is in sorted mode.
+func (l *Treap[T]) Select(k uint) (T, bool) {
+	if !l.sorted {
+		var zero T
+		return zero, false
+	}
+	return l.Get(k)
+}
+
+func (l *Treap[T]) HandleList() []T {
+	var values []T
+	for _, v := range l.All() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// All yields every index and value in list order. Like the LinkedList
+// iterators it must not run concurrently with modifications.
+func (l *Treap[T]) All() iter.Seq2[uint, T] {
+	return allTreap(l.root)
+}
+
+// allTreap yields the values of the tree in order.
+func allTreap[T any](root *treapNode[T]) iter.Seq2[uint, T] {
+	return func(yield func(uint, T) bool) {
+		var stack []*treapNode[T]
+		index := uint(0)
+		node := root
+		for node != nil || len(stack) > 0 {
+			for node != nil {
+				stack = append(stack, node)
+				node = node.left
+			}
+			node = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(index, node.value) {
+				return
+			}
+			index++
+			node = node.right
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package linkedlist
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+// checkTreap reports whether every node of the subtree keeps the heap
+// order of priorities and the size of its subtree.
+func checkTreap(node *treapNode[int]) bool {
+	if node == nil {
+		return true
+	}
+	if node.left != nil && node.left.priority > node.priority {
+		return false
+	}
+	if node.right != nil && node.right.priority > node.priority {
+		return false
+	}
+	return node.size == node.left.sizeOf()+node.right.sizeOf()+1 && checkTreap(node.left) && checkTreap(node.right)
+}
+
+func TestTreapPropertiesQuick(t *testing.T) {
+	err := quick.Check(func(ops []uint16) bool {
+		l := NewTreap[int]()
+		var model []int
+
+		for k, op := range ops {
+			if op%3 == 0 && len(model) > 0 {
+				index := uint(op) % uint(len(model))
+				if !l.Remove(index) {
+					return false
+				}
+				model = slices.Delete(model, int(index), int(index)+1)
+			} else {
+				index := uint(op) % uint(len(model)+1)
+				if !l.Insert(index, k) {
+					return false
+				}
+				model = slices.Insert(model, int(index), k)
+			}
+
+			if l.Len() != uint(len(model)) || !checkTreap(l.root) {
+				return false
+			}
+		}
+
+		for k, v := range model {
+			if out, ok := l.Get(uint(k)); !ok || out != v {
+				return false
+			}
+			if index, found := l.Find(v); !found || index != uint(k) {
+				return false
+			}
+		}
+		return slices.Equal(l.HandleList(), model) && !l.Insert(uint(len(model))+1, 0) && !l.Remove(uint(len(model)))
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTreapRankSelectQuick(t *testing.T) {
+	err := quick.Check(func(values []int8, probes []int8) bool {
+		l := NewTreap[int]()
+		for k, v := range values {
+			l.Insert(uint(k), int(v))
+		}
+		if _, ok := l.Rank(0); ok && len(values) > 0 {
+			return false
+		}
+
+		l.Sort(cmp.Less[int])
+		model := make([]int, len(values))
+		for k, v := range values {
+			model[k] = int(v)
+		}
+		slices.Sort(model)
+
+		for _, p := range append(probes, values...) {
+			if _, ok := l.InsertSorted(int(p)); !ok {
+				return false
+			}
+			at, _ := slices.BinarySearch(model, int(p)+1)
+			model = slices.Insert(model, at, int(p))
+		}
+		if !l.Sorted() || !slices.Equal(l.HandleList(), model) || !checkTreap(l.root) {
+			return false
+		}
+
+		for _, p := range probes {
+			rank, ok := l.Rank(int(p))
+			want, found := slices.BinarySearch(model, int(p))
+			if !ok || rank != uint(want) {
+				return false
+			}
+			if index, ok := l.FindSorted(int(p)); ok != found || (ok && index != uint(want)) {
+				return false
+			}
+		}
+		for k, v := range model {
+			if out, ok := l.Select(uint(k)); !ok || out != v {
+				return false
+			}
+		}
+
+		l.Insert(0, 1000)
+		l.Insert(1, -1000)
+		_, ok := l.Select(0)
+		_, inserted := l.InsertSorted(0)
+		return !ok && !inserted && !l.Sorted() && l.Len() == uint(len(model)+2)
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
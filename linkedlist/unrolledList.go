@@ -0,0 +1,186 @@
+package linkedlist
+
+import "iter"
+
+// unrolledCapacity is the number of values packed into one node.
+const unrolledCapacity = 64
+
+type unrolledNode[T any] struct {
+	values [unrolledCapacity]T
+	count  int
+	next   *unrolledNode[T]
+}
+
+// UnrolledList is a linked list whose nodes each pack up to 64 values in
+// an array. Full nodes split in two on insert, and a node that drops below
+// half full merges with its successor when both fit in one. Compared with
+// LinkedList it allocates one node per 64 values and walks contiguous
+// memory.
+type UnrolledList[T any] struct {
+	head   *unrolledNode[T]
+	tail   *unrolledNode[T]
+	length uint
+	equal  func(a, b T) bool
+}
+
+// NewUnrolled returns an empty unrolled list that compares values with ==.
+func NewUnrolled[T comparable]() *UnrolledList[T] {
+	return NewUnrolledFunc(func(a, b T) bool { return a == b })
+}
+
+// NewUnrolledFunc returns an empty unrolled list that compares values with
+// equal.
+func NewUnrolledFunc[T any](equal func(a, b T) bool) *UnrolledList[T] {
+	return &UnrolledList[T]{equal: equal}
+}
+
+func (l *UnrolledList[T]) Len() uint {
+	return l.length
+}
+
+func (l *UnrolledList[T]) Find(val T) (index uint, found bool) {
+	index = 0
+	for node := l.head; node != nil; node = node.next {
+		for _, v := range node.values[:node.count] {
+			if l.equal(v, val) {
+				return index, true
+			}
+			index++
+		}
+	}
+	return 0, false
+}
+
+func (l *UnrolledList[T]) Get(index uint) (T, bool) {
+	if index >= l.length {
+		var zero T
+		return zero, false
+	}
+
+	_, node, offset := l.locate(index)
+	return node.values[offset], true
+}
+
+func (l *UnrolledList[T]) Insert(index uint, val T) bool {
+	if index > l.length {
+		return false
+	}
+
+	var node *unrolledNode[T]
+	var offset int
+	if index == l.length {
+		if l.tail == nil {
+			l.head = &unrolledNode[T]{}
+			l.tail = l.head
+		}
+		node, offset = l.tail, l.tail.count
+	} else {
+		_, node, offset = l.locate(index)
+	}
+
+	if node.count == unrolledCapacity {
+		node, offset = l.split(node, offset)
+	}
+
+	copy(node.values[offset+1:node.count+1], node.values[offset:node.count])
+	node.values[offset] = val
+	node.count++
+	l.length++
+
+	return true
+}
+
+// split makes room in the full node for an insert at offset and returns
+// the node and offset to insert at. Appending past the last value starts
+// a new node so that sequential appends keep nodes full.
+func (l *UnrolledList[T]) split(node *unrolledNode[T], offset int) (*unrolledNode[T], int) {
+	next := &unrolledNode[T]{next: node.next}
+	node.next = next
+	if l.tail == node {
+		l.tail = next
+	}
+
+	if offset == unrolledCapacity {
+		return next, 0
+	}
+
+	const half = unrolledCapacity / 2
+	next.count = copy(next.values[:], node.values[half:])
+	clear(node.values[half:])
+	node.count = half
+
+	if offset > half {
+		return next, offset - half
+	}
+	return node, offset
+}
+
+func (l *UnrolledList[T]) Remove(index uint) bool {
+	if index >= l.length {
+		return false
+	}
+
+	prev, node, offset := l.locate(index)
+	copy(node.values[offset:], node.values[offset+1:node.count])
+	node.count--
+	var zero T
+	node.values[node.count] = zero
+	l.length--
+
+	if node.count == 0 {
+		l.unlink(prev, node)
+	} else if next := node.next; next != nil && node.count < unrolledCapacity/2 && node.count+next.count <= unrolledCapacity {
+		copy(node.values[node.count:], next.values[:next.count])
+		node.count += next.count
+		l.unlink(node, next)
+	}
+
+	return true
+}
+
+// unlink removes node, which follows prev, or is the head if prev is nil.
+func (l *UnrolledList[T]) unlink(prev, node *unrolledNode[T]) {
+	if prev == nil {
+		l.head = node.next
+	} else {
+		prev.next = node.next
+	}
+	if l.tail == node {
+		l.tail = prev
+	}
+}
+
+// locate returns the node holding index, its predecessor and the offset of
+// index inside it. index must be below l.length.
+func (l *UnrolledList[T]) locate(index uint) (prev, node *unrolledNode[T], offset int) {
+	node = l.head
+	for index >= uint(node.count) {
+		index -= uint(node.count)
+		prev, node = node, node.next
+	}
+	return prev, node, int(index)
+}
+
+func (l *UnrolledList[T]) HandleList() []T {
+	var values []T
+	for node := l.head; node != nil; node = node.next {
+		values = append(values, node.values[:node.count]...)
+	}
+	return values
+}
+
+// All yields every index and value in list order. Like the LinkedList
+// iterators it must not run concurrently with modifications.
+func (l *UnrolledList[T]) All() iter.Seq2[uint, T] {
+	return func(yield func(uint, T) bool) {
+		index := uint(0)
+		for node := l.head; node != nil; node = node.next {
+			for _, v := range node.values[:node.count] {
+				if !yield(index, v) {
+					return
+				}
+				index++
+			}
+		}
+	}
+}
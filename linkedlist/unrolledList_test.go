@@ -0,0 +1,89 @@
+package linkedlist
+
+import (
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+// checkNodes reports whether the node chain of l is consistent with its
+// length and tail, and keeps no empty nodes.
+func checkNodes(l *UnrolledList[int]) bool {
+	var total uint
+	var last *unrolledNode[int]
+	for node := l.head; node != nil; node = node.next {
+		if node.count == 0 || node.count > unrolledCapacity {
+			return false
+		}
+		total += uint(node.count)
+		last = node
+	}
+	return total == l.length && last == l.tail
+}
+
+func TestUnrolledListPropertiesQuick(t *testing.T) {
+	err := quick.Check(func(ops []uint16, appends uint8) bool {
+		l := NewUnrolled[int]()
+		var model []int
+		for i := 0; i < int(appends)*2; i++ {
+			l.Insert(l.Len(), -i)
+			model = append(model, -i)
+		}
+
+		for k, op := range ops {
+			if op%3 == 0 && len(model) > 0 {
+				index := uint(op) % uint(len(model))
+				if !l.Remove(index) {
+					return false
+				}
+				model = slices.Delete(model, int(index), int(index)+1)
+			} else {
+				index := uint(op) % uint(len(model)+1)
+				if !l.Insert(index, k) {
+					return false
+				}
+				model = slices.Insert(model, int(index), k)
+			}
+
+			if !checkNodes(l) {
+				return false
+			}
+		}
+
+		for k, v := range model {
+			if out, ok := l.Get(uint(k)); !ok || out != v {
+				return false
+			}
+		}
+		return slices.Equal(l.HandleList(), model) && !l.Insert(uint(len(model))+1, 0)
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUnrolledListPacking(t *testing.T) {
+	l := NewUnrolled[int]()
+	for i := 0; i < 10*unrolledCapacity; i++ {
+		l.Insert(uint(i), i)
+	}
+
+	nodes := 0
+	for node := l.head; node != nil; node = node.next {
+		nodes++
+	}
+	if nodes != 10 {
+		t.Errorf("Expected appends to fill 10 nodes, got %d", nodes)
+	}
+
+	for l.Len() > 0 {
+		l.Remove(0)
+	}
+	if l.head != nil || l.tail != nil {
+		t.Errorf("Expected no nodes in an empty list, got head %p and tail %p", l.head, l.tail)
+	}
+	if index, found := l.Find(0); found {
+		t.Errorf("Find(0): expected not found in an empty list, got index %d", index)
+	}
+}
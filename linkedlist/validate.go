@@ -0,0 +1,205 @@
+package linkedlist
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrCorrupt is wrapped by the errors of Validate, which describe the
+// broken invariant.
+var ErrCorrupt = errors.New("linkedlist: list is corrupt")
+
+// Validator is implemented by backends that can check their invariants and
+// repair their derived state.
+type Validator interface {
+	Validate() error
+	RebuildIndex()
+}
+
+// cycleStart returns the first node of a cycle reachable from head, or nil
+// if the list ends. It is Floyd's algorithm: once a pointer moving one node
+// at a time meets one moving two, a pointer restarted from head meets the
+// first one again at the start of the cycle.
+func cycleStart[N comparable](head N, next func(N) N) N {
+	var none N
+	slow, fast := head, head
+	for fast != none && next(fast) != none {
+		slow, fast = next(slow), next(next(fast))
+		if slow == fast {
+			for slow = head; slow != fast; slow, fast = next(slow), next(fast) {
+			}
+			return slow
+		}
+	}
+	return none
+}
+
+// cutCycle unlinks the node that closes the cycle starting at start, so
+// that every node stays in the list once.
+func cutCycle[N comparable](start N, next func(N) N, cut func(N)) {
+	last := start
+	for next(last) != start {
+		last = next(last)
+	}
+	cut(last)
+}
+
+// Validate checks that the list has no cycle, that length matches its
+// nodes, that the cached segments start at their recorded positions and
+// cover the list in pieces of at most 2*part nodes, and that the value
+// index holds every node in label order. It returns an error
+// wrapping ErrCorrupt for the first violation.
+func (l *LinkedList[T]) Validate() error {
+	next := func(n *Node[T]) *Node[T] { return n.Next }
+	if cycleStart(l.head, next) != nil {
+		return fmt.Errorf("%w: the nodes form a cycle", ErrCorrupt)
+	}
+
+	var count uint
+	for current := l.head; current != nil; current = current.Next {
+		count++
+	}
+	if count != l.length {
+		return fmt.Errorf("%w: length is %d but there are %d nodes", ErrCorrupt, l.length, count)
+	}
+
+	if l.part == 0 {
+		return fmt.Errorf("%w: segment size is 0", ErrCorrupt)
+	}
+	if len(l.nodes) != len(l.starts) || (len(l.nodes) == 0) != (l.length == 0) {
+		return fmt.Errorf("%w: %d cached segments with %d starts for %d nodes", ErrCorrupt, len(l.nodes), len(l.starts), l.length)
+	}
+	segment := 0
+	var position uint
+	for current := l.head; current != nil; current = current.Next {
+		if segment < len(l.starts) && l.starts[segment] == position {
+			if l.nodes[segment] != current {
+				return fmt.Errorf("%w: segment %d does not start at position %d", ErrCorrupt, segment, position)
+			}
+			segment++
+		}
+		if segment == 0 || position-l.starts[segment-1] >= 2*l.part {
+			return fmt.Errorf("%w: position %d is not within %d nodes of a segment start", ErrCorrupt, position, 2*l.part)
+		}
+		position++
+	}
+	if segment != len(l.starts) {
+		return fmt.Errorf("%w: segment %d starts past the tail", ErrCorrupt, segment)
+	}
+
+	return l.validateIndex()
+}
+
+func (l *LinkedList[T]) validateIndex() error {
+	if l.index == nil {
+		return nil
+	}
+
+	var indexed, expected uint
+	for _, nodes := range l.index.positions {
+		indexed += uint(len(nodes))
+	}
+
+	var prev *Node[T]
+	for current := l.head; current != nil; prev, current = current, current.Next {
+		if prev != nil && prev.label >= current.label {
+			return fmt.Errorf("%w: labels do not increase along the list", ErrCorrupt)
+		}
+		if !selfEqual(current.Value) {
+			continue
+		}
+		expected++
+		nodes := l.index.positions[current.Value]
+		at := sort.Search(len(nodes), func(i int) bool { return nodes[i].label >= current.label })
+		if at == len(nodes) || nodes[at] != current {
+			return fmt.Errorf("%w: a node is missing from the value index", ErrCorrupt)
+		}
+	}
+	if indexed != expected {
+		return fmt.Errorf("%w: the value index holds %d nodes, expected %d", ErrCorrupt, indexed, expected)
+	}
+	return nil
+}
+
+// RebuildIndex repairs everything Validate checks: it cuts a cycle at the
+// link that closes it, recounts the length and rebuilds the segment cache,
+// value index and Bloom filter from the nodes.
+func (l *LinkedList[T]) RebuildIndex() {
+	next := func(n *Node[T]) *Node[T] { return n.Next }
+	if start := cycleStart(l.head, next); start != nil {
+		cutCycle(start, next, func(n *Node[T]) { n.Next = nil })
+	}
+
+	l.length = 0
+	for current := l.head; current != nil; current = current.Next {
+		l.length++
+	}
+	if !l.fixedPart || l.part == 0 {
+		l.part = segmentSizeFor(l.length)
+	}
+	l.rebuildCache()
+	l.reindex()
+	l.refilter()
+}
+
+// Validate checks that the list has no cycle, that every Prev link and
+// tail match the Next links, that length matches the nodes and that
+// segment k of the cache starts at position lead+k*part. It returns an
+// error wrapping ErrCorrupt for the first violation.
+func (l *DoublyLinkedList[T]) Validate() error {
+	next := func(n *DoublyNode[T]) *DoublyNode[T] { return n.Next }
+	if cycleStart(l.head, next) != nil {
+		return fmt.Errorf("%w: the nodes form a cycle", ErrCorrupt)
+	}
+
+	var count uint
+	var prev *DoublyNode[T]
+	for current := l.head; current != nil; prev, current = current, current.Next {
+		if current.Prev != prev {
+			return fmt.Errorf("%w: the Prev link at position %d is wrong", ErrCorrupt, count)
+		}
+		if l.part > 0 && count >= l.lead && (count-l.lead)%l.part == 0 {
+			if segment := (count - l.lead) / l.part; segment >= uint(len(l.nodes)) || l.nodes[segment] != current {
+				return fmt.Errorf("%w: segment %d does not start at position %d", ErrCorrupt, segment, count)
+			}
+		}
+		count++
+	}
+	if l.tail != prev {
+		return fmt.Errorf("%w: tail is not the last node", ErrCorrupt)
+	}
+	if count != l.length {
+		return fmt.Errorf("%w: length is %d but there are %d nodes", ErrCorrupt, l.length, count)
+	}
+
+	if l.part == 0 || l.lead >= l.part {
+		return fmt.Errorf("%w: segment size is %d with %d nodes before the first segment", ErrCorrupt, l.part, l.lead)
+	}
+	if want := l.segments(l.length); len(l.nodes) != want {
+		return fmt.Errorf("%w: %d cached segments, expected %d", ErrCorrupt, len(l.nodes), want)
+	}
+	return nil
+}
+
+// RebuildIndex repairs everything Validate checks: it cuts a cycle at the
+// link that closes it, sets every Prev link and tail from the Next links,
+// recounts the length and rebuilds the segment cache.
+func (l *DoublyLinkedList[T]) RebuildIndex() {
+	next := func(n *DoublyNode[T]) *DoublyNode[T] { return n.Next }
+	if start := cycleStart(l.head, next); start != nil {
+		cutCycle(start, next, func(n *DoublyNode[T]) { n.Next = nil })
+	}
+
+	l.length = 0
+	var prev *DoublyNode[T]
+	for current := l.head; current != nil; prev, current = current, current.Next {
+		current.Prev = prev
+		l.length++
+	}
+	l.tail = prev
+	if !l.fixedPart || l.part == 0 {
+		l.part = segmentSizeFor(l.length)
+	}
+	l.rebuildCache()
+}
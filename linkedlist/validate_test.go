@@ -0,0 +1,108 @@
+package linkedlist
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+func TestValidateQuick(t *testing.T) {
+	err := quick.Check(func(ops []uint16, fixed bool) bool {
+		var opts []Option
+		if fixed {
+			opts = append(opts, WithSegmentSize(3))
+		}
+		l := New[int](append(opts, WithValueIndex())...)
+		d := NewDoubly[int](opts...)
+
+		for k, op := range ops {
+			for _, list := range []List[int]{l, d} {
+				n := list.Len()
+				switch op % 5 {
+				case 0:
+					list.Insert(uint(op)%(n+1), k)
+				case 1:
+					list.Remove(0)
+				case 2:
+					if n > 0 {
+						list.Remove(n - 1)
+					}
+				case 3:
+					InsertAll(list, uint(op)%(n+1), []int{k, k})
+				default:
+					RemoveRange(list, n/3, n/2)
+				}
+				if list.(Validator).Validate() != nil {
+					return false
+				}
+			}
+		}
+		return true
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateDetectsAndRebuildRepairs(t *testing.T) {
+	corruptions := map[string]func(l *LinkedList[int]){
+		"length":  func(l *LinkedList[int]) { l.length++ },
+		"cycle":   func(l *LinkedList[int]) { l.seek(l.length - 1).Next = l.seek(20) },
+		"segment": func(l *LinkedList[int]) { l.nodes[2] = l.nodes[2].Next },
+		"cache":   func(l *LinkedList[int]) { l.nodes = l.nodes[:1] },
+		"index":   func(l *LinkedList[int]) { l.indexRemoved(l.seek(7)) },
+	}
+	for name, corrupt := range corruptions {
+		t.Run(name, func(t *testing.T) {
+			l := New[int](WithSegmentSize(10), WithValueIndex(), WithBloomFilter(100, 0.01))
+			values := make([]int, 50)
+			for i := range values {
+				values[i] = i
+			}
+			l.Append(values...)
+
+			corrupt(l)
+			if err := l.Validate(); !errors.Is(err, ErrCorrupt) {
+				t.Fatalf("expected ErrCorrupt, got %v", err)
+			}
+			l.RebuildIndex()
+			if err := l.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(l.HandleList(), values) {
+				t.Errorf("expected %v, got %v", values, l.HandleList())
+			}
+			if index, found := l.Find(7); !found || index != 7 {
+				t.Errorf("Find(7) = %d, %v after rebuild", index, found)
+			}
+		})
+	}
+}
+
+func TestValidateDoubly(t *testing.T) {
+	corruptions := map[string]func(l *DoublyLinkedList[int]){
+		"prev":  func(l *DoublyLinkedList[int]) { l.seek(5).Prev = l.head },
+		"tail":  func(l *DoublyLinkedList[int]) { l.tail = l.tail.Prev },
+		"cycle": func(l *DoublyLinkedList[int]) { l.tail.Next = l.head },
+	}
+	for name, corrupt := range corruptions {
+		t.Run(name, func(t *testing.T) {
+			l := NewDoubly[int](WithSegmentSize(4))
+			l.Append(1, 2, 3, 4, 5, 6, 7, 8, 9)
+
+			corrupt(l)
+			if err := l.Validate(); !errors.Is(err, ErrCorrupt) {
+				t.Fatalf("expected ErrCorrupt, got %v", err)
+			}
+			l.RebuildIndex()
+			if err := l.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			if got := l.HandleListBackward(); !slices.Equal(got, []int{9, 8, 7, 6, 5, 4, 3, 2, 1}) {
+				t.Errorf("expected the list backwards, got %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package linkedlist
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// valueIndex maps every value of a list to its nodes in list order.
+//
+// It stores nodes rather than positions, since an insert or remove would
+// shift every position after it. Instead, each node carries a label that
+// increases along the list, which orders the nodes of a value and locates
+// a node among the cached segment starts. A new node takes a label between
+// its neighbours'; when they are adjacent, only the nodes of the smallest
+// sparse enough label range around them are labelled again. Relabelling
+// keeps the order, so the index is left as is.
+type valueIndex[T any] struct {
+	// Keys are values boxed in an interface, which is why only lists
+	// created by New, whose T is comparable, have an index.
+	positions map[any][]*Node[T]
+}
+
+// WithValueIndex makes Find, FindAll and Count look values up in an index
+// instead of scanning the list, at the cost of a map entry per node and an
+// O(log k) update on every insert and remove, where k is the number of
+// copies of the value. Like ==, the index matches NaN with nothing. It
+// applies to lists created by New; NewFunc lists compare with a function
+// that a map cannot use.
+func WithValueIndex() Option {
+	return func(o *options) {
+		o.valueIndex = true
+	}
+}
+
+func newValueIndex[T any]() *valueIndex[T] {
+	return &valueIndex[T]{positions: make(map[any][]*Node[T])}
+}
+
+// add inserts node among the nodes of its value by label.
+func (x *valueIndex[T]) add(node *Node[T]) {
+	if !selfEqual(node.Value) {
+		return
+	}
+	nodes := x.positions[node.Value]
+	at := sort.Search(len(nodes), func(i int) bool { return nodes[i].label > node.label })
+	x.positions[node.Value] = slices.Insert(nodes, at, node)
+}
+
+func (x *valueIndex[T]) remove(node *Node[T]) {
+	nodes := x.positions[node.Value]
+	at := sort.Search(len(nodes), func(i int) bool { return nodes[i].label >= node.label })
+	if at == len(nodes) || nodes[at] != node {
+		return
+	}
+	if len(nodes) == 1 {
+		delete(x.positions, node.Value)
+		return
+	}
+	x.positions[node.Value] = slices.Delete(nodes, at, at+1)
+}
+
+// selfEqual reports whether v == v. That fails for NaN and for values that
+// hold one: == matches them with nothing, and a map cannot find them as
+// keys again, so the index and the Bloom filter leave them out.
+func selfEqual(v any) bool {
+	return v == v
+}
+
+// indexInserted labels the count nodes starting at first, which were just
+// linked after prev, or at the head if prev is nil, and indexes them.
+func (l *LinkedList[T]) indexInserted(prev, first *Node[T], count uint) {
+	if l.index == nil {
+		return
+	}
+
+	// Labels 0 and math.MaxUint64 stand for the ends of the list.
+	low, high := uint64(0), uint64(math.MaxUint64)
+	if prev != nil {
+		low = prev.label
+	}
+	after := first
+	for i := uint(0); i < count; i++ {
+		after = after.Next
+	}
+	if after != nil {
+		high = after.label
+	}
+
+	if step := (high - low) / uint64(count+1); step > 0 {
+		for current, label := first, low+step; current != after; current, label = current.Next, label+step {
+			current.label = label
+		}
+	} else {
+		l.relabelAround(prev, first, count)
+	}
+
+	for current := first; current != after; current = current.Next {
+		l.index.add(current)
+	}
+}
+
+// indexRemoved drops node, which is about to be unlinked, from the index.
+func (l *LinkedList[T]) indexRemoved(node *Node[T]) {
+	if l.index != nil {
+		l.index.remove(node)
+	}
+}
+
+// relabelAround makes room for the count nodes starting at first, which
+// were linked after prev, or at the head if prev is nil, between labels
+// that left no gap. It is the order maintenance of Bender et al.: the
+// aligned range of 2^i labels around prev overflows if it holds 1.5^i
+// nodes or more, and the nodes of the smallest range that does not are
+// spread evenly over it. That relabels an amortized O(log n) nodes per
+// insert instead of the whole list.
+func (l *LinkedList[T]) relabelAround(prev, first *Node[T], count uint) {
+	var base uint64
+	if prev != nil {
+		base = prev.label
+	}
+
+	for level := 1; level < 64; level++ {
+		lo := base &^ (1<<level - 1)
+		hi := lo + (1<<level - 1)
+		nodes := l.labelRange(prev, first, count, lo, hi, func(*Node[T]) {})
+		step := (hi - lo) / uint64(nodes+1)
+		if float64(nodes) >= math.Pow(1.5, float64(level)) || step == 0 {
+			continue
+		}
+
+		label := lo
+		l.labelRange(prev, first, count, lo, hi, func(node *Node[T]) {
+			label += step
+			node.label = label
+		})
+		return
+	}
+	l.relabel()
+}
+
+// labelRange calls visit for the nodes labelled within [lo, hi] in list
+// order, counting the count unlabelled nodes starting at first, which
+// follow prev, as within it too, and returns how many it visited. The
+// cached segment starts carry labels, so it walks at most one segment
+// before the range.
+func (l *LinkedList[T]) labelRange(prev, first *Node[T], count uint, lo, hi uint64, visit func(*Node[T])) uint {
+	current, fresh := first, count
+	if prev != nil {
+		current, fresh = l.head, 0
+		if segment := sort.Search(len(l.nodes), func(i int) bool { return l.nodes[i].label >= lo }); segment > 0 {
+			current = l.nodes[segment-1]
+		}
+		for current.label < lo {
+			current = current.Next
+		}
+	}
+
+	var visited uint
+	for ; current != nil; current = current.Next {
+		if fresh > 0 {
+			fresh--
+		} else if current.label > hi {
+			break
+		}
+		visit(current)
+		visited++
+		if current == prev {
+			fresh = count
+		}
+	}
+	return visited
+}
+
+// relabel spreads the labels of all nodes evenly over the label space.
+func (l *LinkedList[T]) relabel() {
+	step := math.MaxUint64 / uint64(l.length+1)
+	label := step
+	for current := l.head; current != nil; current = current.Next {
+		current.label = label
+		label += step
+	}
+}
+
+// reindex labels and indexes the list from scratch after its nodes were
+// reordered.
+func (l *LinkedList[T]) reindex() {
+	if l.index == nil {
+		return
+	}
+
+	l.relabel()
+	l.index = newValueIndex[T]()
+	for current := l.head; current != nil; current = current.Next {
+		if selfEqual(current.Value) {
+			l.index.positions[current.Value] = append(l.index.positions[current.Value], current)
+		}
+	}
+}
+
+// indexOf returns the position of node. It binary searches the cached
+// segment starts by label and walks at most one segment.
+func (l *LinkedList[T]) indexOf(node *Node[T]) uint {
+	segment := sort.Search(len(l.nodes), func(i int) bool { return l.nodes[i].label > node.label }) - 1
+
+	index := l.starts[segment]
+	for current := l.nodes[segment]; current != node; current = current.Next {
+		index++
+	}
+	return index
+}
+
+// FindAll returns the index of every element equal to val, in order.
+func (l *LinkedList[T]) FindAll(val T) []uint {
+	var indices []uint
+	if l.index != nil {
+		for _, node := range l.index.positions[val] {
+			indices = append(indices, l.indexOf(node))
+		}
+		return indices
+	}
+
+	for i, v := range l.All() {
+		if l.equal(v, val) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// Count returns the number of elements equal to val.
+func (l *LinkedList[T]) Count(val T) uint {
+	if l.index != nil {
+		return uint(len(l.index.positions[val]))
+	}
+
+	count := uint(0)
+	for _, v := range l.All() {
+		if l.equal(v, val) {
+			count++
+		}
+	}
+	return count
+}
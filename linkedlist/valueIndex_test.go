@@ -0,0 +1,200 @@
+package linkedlist
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+// checkLabels reports whether the labels of l increase along the list.
+func checkLabels(l *LinkedList[int]) bool {
+	for current := l.head; current != nil && current.Next != nil; current = current.Next {
+		if current.label >= current.Next.label {
+			return false
+		}
+	}
+	return true
+}
+
+func TestValueIndexQuick(t *testing.T) {
+	err := quick.Check(func(ops []uint16) bool {
+		l := New[int](WithValueIndex(), WithSegmentSize(4))
+		var model []int
+
+		for k, op := range ops {
+			// Few distinct values, so most of them have several copies.
+			v := k % 5
+			switch {
+			case op%8 == 0 && len(model) > 0:
+				index := uint(op) % uint(len(model))
+				l.Remove(index)
+				model = slices.Delete(model, int(index), int(index)+1)
+			case op%8 == 1:
+				index := uint(op) % uint(len(model)+1)
+				l.InsertAll(index, []int{v, v + 1, v})
+				model = slices.Insert(model, int(index), v, v+1, v)
+			case op%8 == 2 && len(model) > 0:
+				from := uint(op) % uint(len(model))
+				to := min(from+3, uint(len(model)))
+				l.RemoveRange(from, to)
+				model = slices.Delete(model, int(from), int(to))
+			case op%8 == 3:
+				l.Sort(cmp.Less[int])
+				slices.SortStableFunc(model, cmp.Compare[int])
+			case op%8 == 4:
+				l.Rotate(int(op))
+				if n := len(model); n > 0 {
+					shift := int(op) % n
+					model = slices.Concat(model[shift:], model[:shift])
+				}
+			case op%8 == 5:
+				other := New[int](WithValueIndex())
+				other.Append(v, v)
+				index := uint(op) % uint(len(model)+1)
+				l.Splice(index, other)
+				model = slices.Insert(model, int(index), v, v)
+				if other.Count(v) != 0 {
+					return false
+				}
+			default:
+				index := uint(op) % uint(len(model)+1)
+				l.Insert(index, v)
+				model = slices.Insert(model, int(index), v)
+			}
+
+			if !checkLabels(l) {
+				return false
+			}
+		}
+
+		for v := range 7 {
+			var want []uint
+			for i, m := range model {
+				if m == v {
+					want = append(want, uint(i))
+				}
+			}
+			if got := l.FindAll(v); !slices.Equal(got, want) || l.Count(v) != uint(len(want)) {
+				return false
+			}
+			index, found := l.Find(v)
+			if found != (len(want) > 0) || (found && index != want[0]) {
+				return false
+			}
+		}
+		return slices.Equal(l.HandleList(), model)
+	}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestValueIndexRelabel inserts between the same two nodes until their
+// labels run out of room, which makes the list relabel itself.
+func TestValueIndexRelabel(t *testing.T) {
+	l := New[int](WithValueIndex())
+	l.Append(0, 0)
+	for i := 1; i <= 200; i++ {
+		l.Insert(1, i)
+		if !checkLabels(l) {
+			t.Fatalf("labels out of order after %d inserts", i)
+		}
+	}
+
+	for i := 1; i <= 200; i++ {
+		if index, found := l.Find(i); !found || index != uint(201-i) {
+			t.Errorf("Find(%d): expected index %d, got index %d, found %t", i, 201-i, index, found)
+		}
+	}
+	if got := l.FindAll(0); !slices.Equal(got, []uint{0, 201}) {
+		t.Errorf("FindAll(0): expected [0 201], got %v", got)
+	}
+}
+
+// TestValueIndexRelabelLocally inserts into the same gap over and over,
+// the worst case for labels, and checks that the relabelling stays near the
+// inserts instead of rewriting the whole list each time the gap runs out.
+func TestValueIndexRelabelLocally(t *testing.T) {
+	const n, inserts = 1000, 3000
+	labels := func(l *LinkedList[int]) []uint64 {
+		var out []uint64
+		for current := l.head; current != nil; current = current.Next {
+			out = append(out, current.label)
+		}
+		return out
+	}
+
+	for name, at := range map[string]func(i int) uint{
+		"before the last insert": func(int) uint { return n / 2 },
+		"after the last insert":  func(i int) uint { return uint(n/2 + i) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			l := New[int](WithValueIndex())
+			l.Append(make([]int, n)...)
+
+			relabelled := 0
+			before := labels(l)
+			for i := range inserts {
+				index := at(i)
+				l.Insert(index, i+1)
+				after := labels(l)
+				for k, label := range slices.Delete(slices.Clone(after), int(index), int(index)+1) {
+					if label != before[k] {
+						relabelled++
+					}
+				}
+				before = after
+			}
+
+			if err := l.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			if per := relabelled / inserts; per > 20 {
+				t.Errorf("relabelled %d nodes per insert, expected O(log n)", per)
+			}
+		})
+	}
+}
+
+func TestValueIndexSplit(t *testing.T) {
+	l := New[int](WithValueIndex())
+	l.Append(1, 2, 1, 2, 1)
+	front, back := l.Split(2)
+
+	if l.Count(1) != 0 || front.Count(1) != 1 || back.Count(1) != 2 {
+		t.Errorf("expected counts 0, 1 and 2, got %d, %d and %d", l.Count(1), front.Count(1), back.Count(1))
+	}
+	if got := back.FindAll(1); !slices.Equal(got, []uint{0, 2}) {
+		t.Errorf("expected [0 2], got %v", got)
+	}
+	if NewFunc(func(a, b int) bool { return a == b }, WithValueIndex()).index != nil {
+		t.Error("NewFunc list has a value index")
+	}
+}
+
+// TestValueIndexNaN checks that an indexed list treats NaN like ==, which
+// matches it with nothing, and can still remove it.
+func TestValueIndexNaN(t *testing.T) {
+	l := New[float64](WithValueIndex())
+	l.Append(1.5, math.NaN(), 1.5)
+	l.Insert(0, math.NaN())
+
+	if err := l.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if _, found := l.Find(math.NaN()); found || l.Count(math.NaN()) != 0 {
+		t.Error("found NaN, which == matches with nothing")
+	}
+	if !l.Remove(0) || !l.Remove(1) {
+		t.Fatal("Remove failed")
+	}
+	if err := l.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if got := l.FindAll(1.5); !slices.Equal(got, []uint{0, 1}) {
+		t.Errorf("expected [0 1], got %v", got)
+	}
+}
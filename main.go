@@ -64,7 +64,14 @@ func ConfigureLoggerLevel() {
 }
 
 func configChanged(oldConfig *config.Config) ConfigChangeType {
-	if oldConfig.Server.Port != config.Confs.Server.Port {
+	if oldConfig.Server.Port != config.Confs.Server.Port ||
+		oldConfig.Server.RequestTimeout != config.Confs.Server.RequestTimeout ||
+		oldConfig.Storage.Backend != config.Confs.Storage.Backend ||
+		oldConfig.Storage.V2Backend != config.Confs.Storage.V2Backend ||
+		oldConfig.Storage.ValueIndex != config.Confs.Storage.ValueIndex ||
+		oldConfig.Storage.BloomCapacity != config.Confs.Storage.BloomCapacity ||
+		oldConfig.Storage.BloomFalsePositiveRate != config.Confs.Storage.BloomFalsePositiveRate ||
+		oldConfig.Storage.Capacity != config.Confs.Storage.Capacity {
 		return serverChange
 	}
 